@@ -0,0 +1,136 @@
+package goinertia
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// assetVersionLength is the number of hex characters kept from the content hash.
+const assetVersionLength = 16
+
+// assetVersionSource describes the files the asset version is derived from.
+// A nil fsys means the public FS; empty paths mean the Vite manifest.
+type assetVersionSource struct {
+	fsys  fs.FS
+	paths []string
+
+	// mu guards the dev mode cache: version is the hash of the files when their sizes and modtimes gave stamp.
+	mu      sync.Mutex
+	stamp   string
+	version string
+}
+
+// initAssetVersion computes the asset version once at startup.
+// On failure the static version from WithAssetVersion is kept.
+func (i *Inertia) initAssetVersion() {
+	if i.assetVersionSource == nil {
+		return
+	}
+
+	version, err := i.computeAssetVersion()
+	if err != nil {
+		i.assetVersionErr = err
+		i.logger.ErrorContext(context.Background(), "could not compute asset version", "error", err)
+		return
+	}
+
+	i.assetVersion = version
+}
+
+// currentAssetVersion returns the asset version for the current request.
+// In dev mode a derived version is rehashed whenever the size or modtime of a file changes,
+// so rebuilt assets trigger 409 reloads.
+func (i *Inertia) currentAssetVersion() string {
+	if i.assetVersionSource == nil || !i.isDev {
+		return i.assetVersion
+	}
+
+	src := i.assetVersionSource
+	fsys, paths := i.assetVersionFiles()
+	stamp, err := walkAssetVersionFiles(fsys, paths, func(hash io.Writer, path string, d fs.DirEntry) error {
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(hash, "%s\x00%d\x00%d\x00", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return i.assetVersion
+	}
+
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	if src.version != "" && src.stamp == stamp {
+		return src.version
+	}
+
+	version, err := i.computeAssetVersion()
+	if err != nil {
+		return i.assetVersion
+	}
+	src.stamp, src.version = stamp, version
+
+	return version
+}
+
+func (i *Inertia) computeAssetVersion() (string, error) {
+	fsys, paths := i.assetVersionFiles()
+	return walkAssetVersionFiles(fsys, paths, func(hash io.Writer, path string, _ fs.DirEntry) error {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		_, _ = hash.Write([]byte(path))
+		_, _ = hash.Write([]byte{0})
+		_, _ = hash.Write(data)
+		return nil
+	})
+}
+
+func (i *Inertia) assetVersionFiles() (fs.FS, []string) {
+	fsys := i.assetVersionSource.fsys
+	if fsys == nil {
+		fsys = i.publicFS
+	}
+	if fsys == nil {
+		fsys = os.DirFS(".")
+	}
+
+	paths := i.assetVersionSource.paths
+	if len(paths) == 0 {
+		paths = []string{i.viteManifestPath}
+	}
+	return fsys, paths
+}
+
+// walkAssetVersionFiles hashes what write adds for every file under paths.
+func walkAssetVersionFiles(
+	fsys fs.FS,
+	paths []string,
+	write func(hash io.Writer, path string, d fs.DirEntry) error,
+) (string, error) {
+	hash := sha256.New()
+	for _, root := range paths {
+		err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			return write(hash, path, d)
+		})
+		if err != nil {
+			return "", fmt.Errorf("error hashing asset version source %q: %w", root, err)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:assetVersionLength], nil
+}
@@ -0,0 +1,141 @@
+package goinertia
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetVersion_FromManifest(t *testing.T) {
+	t.Parallel()
+
+	publicFS := fstest.MapFS{
+		DefaultViteManifestPath: {Data: []byte(`{"src/app.js":{"file":"assets/app-1.js"}}`)},
+	}
+
+	inrt := New("http://example.com", WithPublicFS(publicFS), WithAssetVersionFromManifest())
+	require.NoError(t, inrt.assetVersionErr)
+	assert.Len(t, inrt.assetVersion, assetVersionLength)
+	assert.Equal(t, inrt.assetVersion, inrt.currentAssetVersion())
+
+	other := New("http://example.com", WithPublicFS(fstest.MapFS{
+		DefaultViteManifestPath: {Data: []byte(`{"src/app.js":{"file":"assets/app-2.js"}}`)},
+	}), WithAssetVersionFromManifest())
+	assert.NotEqual(t, inrt.assetVersion, other.assetVersion)
+}
+
+func TestAssetVersion_FromFSSubtree(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"dist/app.js":      {Data: []byte("console.log(1)")},
+		"dist/css/app.css": {Data: []byte("body{}")},
+		"other/skip.txt":   {Data: []byte("ignored")},
+	}
+
+	inrt := New("http://example.com", WithAssetVersionFromFS(fsys, "dist"))
+	require.NoError(t, inrt.assetVersionErr)
+	version := inrt.assetVersion
+
+	fsys["other/skip.txt"] = &fstest.MapFile{Data: []byte("changed")}
+	assert.Equal(t, version, New("http://example.com", WithAssetVersionFromFS(fsys, "dist")).assetVersion)
+
+	fsys["dist/css/app.css"] = &fstest.MapFile{Data: []byte("body{color:red}")}
+	assert.NotEqual(t, version, New("http://example.com", WithAssetVersionFromFS(fsys, "dist")).assetVersion)
+}
+
+func TestAssetVersion_MissingSource(t *testing.T) {
+	t.Parallel()
+
+	inrt := New("http://example.com",
+		WithAssetVersion("static"),
+		WithAssetVersionFromFS(fstest.MapFS{}, "missing.json"),
+	)
+	require.Error(t, inrt.assetVersionErr)
+	assert.Equal(t, "static", inrt.currentAssetVersion())
+
+	_, err := NewWithValidation("http://example.com", WithAssetVersionFromFS(fstest.MapFS{}, "missing.json"))
+	require.Error(t, err)
+}
+
+func TestAssetVersion_DevModeRecompute(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	manifest := filepath.Join(tmpDir, "manifest.json")
+	require.NoError(t, os.WriteFile(manifest, []byte(`{"v":1}`), 0o600))
+	publicFS, ok := os.DirFS(tmpDir).(fs.ReadFileFS)
+	require.True(t, ok)
+
+	inrt := New("http://example.com",
+		WithPublicFS(publicFS),
+		WithViteManifest("manifest.json"),
+		WithAssetVersionFromManifest(),
+		WithDevMode(),
+	)
+	first := inrt.currentAssetVersion()
+	assert.Equal(t, inrt.assetVersion, first)
+
+	// The size changes too: two writes may share a modtime on filesystems with coarse timestamps.
+	require.NoError(t, os.WriteFile(manifest, []byte(`{"v":22}`), 0o600))
+	assert.NotEqual(t, first, inrt.currentAssetVersion())
+
+	cached := New("http://example.com",
+		WithPublicFS(publicFS),
+		WithViteManifest("manifest.json"),
+		WithAssetVersionFromManifest(),
+	)
+	version := cached.currentAssetVersion()
+	require.NoError(t, os.WriteFile(manifest, []byte(`{"v":3}`), 0o600))
+	assert.Equal(t, version, cached.currentAssetVersion())
+}
+
+// readCountingFS counts the files read, i.e. how often the asset version is rehashed.
+type readCountingFS struct {
+	fstest.MapFS
+
+	reads atomic.Int32
+}
+
+func (f *readCountingFS) ReadFile(name string) ([]byte, error) {
+	f.reads.Add(1)
+	return f.MapFS.ReadFile(name)
+}
+
+func TestAssetVersion_DevModeCachesHash(t *testing.T) {
+	t.Parallel()
+
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fsys := &readCountingFS{MapFS: fstest.MapFS{
+		"dist/app.js": {Data: []byte("console.log(1)"), ModTime: modTime},
+	}}
+
+	inrt := New("http://example.com", WithAssetVersionFromFS(fsys, "dist"), WithDevMode())
+	require.NoError(t, inrt.assetVersionErr)
+	fsys.reads.Store(0)
+
+	version := inrt.currentAssetVersion()
+	for range 5 {
+		assert.Equal(t, version, inrt.currentAssetVersion())
+	}
+	assert.Equal(t, int32(1), fsys.reads.Load())
+
+	// Same size, new modtime: rehashed once.
+	fsys.MapFS["dist/app.js"] = &fstest.MapFile{Data: []byte("console.log(2)"), ModTime: modTime.Add(time.Second)}
+	changed := inrt.currentAssetVersion()
+	assert.NotEqual(t, version, changed)
+	assert.Equal(t, changed, inrt.currentAssetVersion())
+	assert.Equal(t, int32(2), fsys.reads.Load())
+
+	// Same modtime, new size: rehashed once.
+	fsys.MapFS["dist/app.js"] = &fstest.MapFile{Data: []byte("console.log(33)"), ModTime: modTime.Add(time.Second)}
+	assert.NotEqual(t, changed, inrt.currentAssetVersion())
+	assert.Equal(t, int32(3), fsys.reads.Load())
+}
@@ -0,0 +1,240 @@
+// Command goinertia-gen writes TypeScript declarations for Inertia page components.
+//
+// Page props are declared in Go with goinertia.RegisterPage (usually from an init function).
+// goinertia-gen builds a small program that imports those packages and runs goinertia.GenerateTypeScript:
+//
+//	//go:generate go run github.com/assurrussa/goinertia/cmd/goinertia-gen -pkg ./pages -out ../web/src/types/inertia.d.ts
+//
+// With -inertia, the program calls a function of the application returning its *goinertia.Inertia and runs
+// its GenerateTypeScript method, so the props configured with WithSharedProps are declared too:
+//
+//	goinertia-gen -pkg ./pages -inertia ./web.NewInertia -out ../web/src/types/inertia.d.ts
+//
+// It must be run inside the module that contains the packages.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var (
+	errNoPackages       = errors.New("at least one package is required (-pkg or -inertia)")
+	errInvalidInertiaFn = errors.New("-inertia must have the form <package>.<Function>")
+)
+
+// program is the data of programTemplate.
+type program struct {
+	Imports []string
+	// InertiaPkg and InertiaFunc name the function returning the *goinertia.Inertia, if any.
+	InertiaPkg  string
+	InertiaFunc string
+}
+
+var programTemplate = template.Must(template.New("main").Parse(`// Code generated by goinertia-gen. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+{{- if .InertiaPkg}}
+	inertiaapp {{.InertiaPkg}}
+{{- else}}
+	"github.com/assurrussa/goinertia"
+{{- end}}
+{{range .Imports}}
+	_ {{.}}
+{{- end}}
+)
+
+func main() {
+	f, err := os.Create(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+{{- if .InertiaPkg}}
+	if err := inertiaapp.{{.InertiaFunc}}().GenerateTypeScript(f); err != nil {
+{{- else}}
+	if err := goinertia.GenerateTypeScript(f); err != nil {
+{{- end}}
+		_ = f.Close()
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+func main() {
+	var (
+		pkgs      string
+		inertiaFn string
+		out       string
+		tags      string
+	)
+	flag.StringVar(&pkgs, "pkg", "", "comma-separated packages that register pages (import paths or ./relative dirs)")
+	flag.StringVar(&inertiaFn, "inertia", "",
+		"function returning the *goinertia.Inertia of the app, e.g. ./web.NewInertia, to declare WithSharedProps props")
+	flag.StringVar(&out, "out", "inertia.d.ts", "output .d.ts file")
+	flag.StringVar(&tags, "tags", "", "comma-separated build tags")
+	flag.Parse()
+
+	if err := run(context.Background(), splitList(pkgs), inertiaFn, out, tags); err != nil {
+		log.Fatalf("goinertia-gen: %v", err)
+	}
+}
+
+func run(ctx context.Context, pkgs []string, inertiaFn string, out string, tags string) error {
+	if len(pkgs) == 0 && inertiaFn == "" {
+		return errNoPackages
+	}
+
+	var prog program
+	if inertiaFn != "" {
+		pkg, fn, err := splitFuncRef(inertiaFn)
+		if err != nil {
+			return err
+		}
+		resolved, err := resolvePackages(ctx, []string{pkg}, tags)
+		if err != nil {
+			return err
+		}
+		if len(resolved) != 1 {
+			return fmt.Errorf("%w: %q matches %d packages", errInvalidInertiaFn, pkg, len(resolved))
+		}
+		prog.InertiaPkg, prog.InertiaFunc = resolved[0], fn
+	}
+	if len(pkgs) > 0 {
+		importPaths, err := resolvePackages(ctx, pkgs, tags)
+		if err != nil {
+			return err
+		}
+		prog.Imports = importPaths
+	}
+
+	out, err := filepath.Abs(out)
+	if err != nil {
+		return fmt.Errorf("resolve output path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0o750); err != nil {
+		return fmt.Errorf("create output dir: %w", err)
+	}
+
+	// The program lives inside the current module so the imports resolve against its go.mod.
+	dir, err := os.MkdirTemp(".", ".goinertia-gen-")
+	if err != nil {
+		return fmt.Errorf("create temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	src, err := renderProgram(prog)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), src, 0o600); err != nil {
+		return fmt.Errorf("write program: %w", err)
+	}
+
+	return goCommand(ctx, nil, "run", tags, "./"+filepath.ToSlash(dir), out)
+}
+
+// resolvePackages turns relative directories into import paths.
+func resolvePackages(ctx context.Context, pkgs []string, tags string) ([]string, error) {
+	var stdout bytes.Buffer
+	args := append([]string{"-f", "{{.ImportPath}}"}, pkgs...)
+	if err := goCommand(ctx, &stdout, "list", tags, args...); err != nil {
+		return nil, err
+	}
+	return splitLines(stdout.String()), nil
+}
+
+// splitFuncRef splits "./web.NewInertia" into the package and the function name.
+func splitFuncRef(ref string) (string, string, error) {
+	slash := strings.LastIndex(ref, "/")
+	dot := strings.LastIndex(ref, ".")
+	if dot <= slash+1 || dot == len(ref)-1 {
+		return "", "", fmt.Errorf("%w: %q", errInvalidInertiaFn, ref)
+	}
+	return ref[:dot], ref[dot+1:], nil
+}
+
+func renderProgram(prog program) ([]byte, error) {
+	data := program{InertiaFunc: prog.InertiaFunc}
+	if prog.InertiaPkg != "" {
+		data.InertiaPkg = strconv.Quote(prog.InertiaPkg)
+	}
+	for _, path := range prog.Imports {
+		// The package of the Inertia function is already imported by name.
+		if path != prog.InertiaPkg {
+			data.Imports = append(data.Imports, strconv.Quote(path))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := programTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render program: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format program: %w", err)
+	}
+	return src, nil
+}
+
+func goCommand(ctx context.Context, stdout *bytes.Buffer, command string, tags string, args ...string) error {
+	cmdArgs := []string{command}
+	if tags != "" {
+		cmdArgs = append(cmdArgs, "-tags", tags)
+	}
+	cmdArgs = append(cmdArgs, args...)
+
+	// #nosec G204 - arguments come from the command line of the developer running the generator
+	cmd := exec.CommandContext(ctx, "go", cmdArgs...)
+	cmd.Stderr = os.Stderr
+	cmd.Stdout = os.Stdout
+	if stdout != nil {
+		cmd.Stdout = stdout
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go %s: %w", command, err)
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func splitLines(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, "\n") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
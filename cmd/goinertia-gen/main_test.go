@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFuncRef(t *testing.T) {
+	t.Parallel()
+
+	pkg, fn, err := splitFuncRef("./web.NewInertia")
+	require.NoError(t, err)
+	assert.Equal(t, "./web", pkg)
+	assert.Equal(t, "NewInertia", fn)
+
+	pkg, fn, err = splitFuncRef("example.com/app/web.New")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/app/web", pkg)
+	assert.Equal(t, "New", fn)
+
+	for _, ref := range []string{"NewInertia", "./web.", "example.com/app"} {
+		_, _, err = splitFuncRef(ref)
+		require.ErrorIs(t, err, errInvalidInertiaFn, ref)
+	}
+}
+
+func TestRenderProgram(t *testing.T) {
+	t.Parallel()
+
+	src, err := renderProgram(program{Imports: []string{"example.com/app/pages"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(src), `"github.com/assurrussa/goinertia"`)
+	assert.Contains(t, string(src), `_ "example.com/app/pages"`)
+	assert.Contains(t, string(src), "goinertia.GenerateTypeScript(f)")
+
+	src, err = renderProgram(program{
+		Imports:     []string{"example.com/app/pages", "example.com/app/web"},
+		InertiaPkg:  "example.com/app/web",
+		InertiaFunc: "NewInertia",
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, string(src), `"github.com/assurrussa/goinertia"`)
+	assert.Contains(t, string(src), `inertiaapp "example.com/app/web"`)
+	assert.NotContains(t, string(src), `_ "example.com/app/web"`)
+	assert.Contains(t, string(src), "inertiaapp.NewInertia().GenerateTypeScript(f)")
+}
@@ -49,3 +49,10 @@ const (
 	ContextPropsFlash     = "flash"
 	ContextPropsCSRFToken = "csrf_token"
 )
+
+// flashKeyErrorBags holds the flashed errors grouped by the error bag of the submitting form.
+const flashKeyErrorBags = "errorBags"
+
+// DefaultErrorBag groups the errors of a request without X-Inertia-Error-Bag when they are shown next to
+// error bags restored from the session.
+const DefaultErrorBag = "default"
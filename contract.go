@@ -2,6 +2,9 @@ package goinertia
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/gofiber/fiber/v3"
 )
@@ -11,6 +14,10 @@ import (
 type (
 	CSRFTokenProvider      func(c fiber.Ctx) (string, error)
 	CSRFTokenCheckProvider func(c fiber.Ctx) error
+
+	// HTTPCSRFTokenProvider and HTTPCSRFTokenCheckProvider are the CSRF providers of the net/http adapter.
+	HTTPCSRFTokenProvider      func(r *http.Request) (string, error)
+	HTTPCSRFTokenCheckProvider func(r *http.Request) error
 )
 
 type SessionStore interface {
@@ -21,6 +28,20 @@ type SessionStore interface {
 	GetFlash(c fiber.Ctx, key string) (any, error)
 }
 
+// HTTPSessionStore is the session storage used by the net/http adapter.
+// It is keyed by the request context, which fits context-based session managers.
+type HTTPSessionStore interface {
+	Flash(ctx context.Context, key string, value any) error
+	GetFlash(ctx context.Context, key string) (any, error)
+}
+
+// HTTPSessionGetter is implemented by an HTTPSessionStore that can read a key without consuming it.
+// The error bags of a redirect are merged with the pending flash through it; without it the flash is
+// read with GetFlash and written again.
+type HTTPSessionGetter interface {
+	Get(ctx context.Context, key string) (any, error)
+}
+
 type SessionAdapter[T FiberSessionStore] interface {
 	Get(c fiber.Ctx) (T, error)
 }
@@ -43,3 +64,53 @@ type SSRClient interface {
 	Reset()
 	Post(ctx context.Context, url string, body []byte, headers map[string]string) (int, []byte, error)
 }
+
+// SSRHealthClient is implemented by SSR clients that can probe the health endpoint.
+// SSRClient implementations without it are probed with the default HTTP client.
+type SSRHealthClient interface {
+	Get(ctx context.Context, url string, headers map[string]string) (int, []byte, error)
+}
+
+// SSRCache stores SSR results between renders. Keys have the form "<component>:<hex hash>".
+// A ttl of zero means the entry does not expire. Errors are logged and treated as cache misses.
+type SSRCache interface {
+	Get(ctx context.Context, key string) (*SsrDTO, bool, error)
+	Set(ctx context.Context, key string, value *SsrDTO, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	Purge(ctx context.Context) error
+}
+
+// SSRCachePrefixDeleter is implemented by SSRCache backends that can drop all keys with a prefix.
+// It is used by Inertia.InvalidateSSRComponent.
+type SSRCachePrefixDeleter interface {
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// SSRCacheStatsProvider is implemented by SSRCache backends that count their own activity.
+type SSRCacheStatsProvider interface {
+	Stats() SSRCacheStats
+}
+
+// FileStorage persists uploaded files. Save returns the location of the stored file.
+type FileStorage interface {
+	Save(ctx context.Context, name string, src io.Reader) (string, error)
+}
+
+// FileRemover is implemented by a FileStorage that can delete the location returned by Save.
+// ProcessFileUploads uses it to delete the files already stored when a later file of the batch fails.
+type FileRemover interface {
+	Delete(ctx context.Context, path string) error
+}
+
+// Translator localizes the texts goinertia produces itself (see the Message* keys, MessagesEN and MessagesRU).
+// Translate reports false when it has no text for the key in the locale.
+type Translator interface {
+	Translate(locale, key string) (string, bool)
+}
+
+// Validator checks a request value bound by Inertia.BindAndValidate. It returns the failed fields keyed by
+// path, and an error only when validation itself could not run. Paths may use Go field names and brackets
+// ("Items[0].Name"); they are converted to the dotted keys of the bound struct tags ("items.0.name").
+type Validator interface {
+	Validate(ctx context.Context, value any) (ValidationErrors, error)
+}
@@ -1,5 +1,7 @@
 package goinertia
 
+import "time"
+
 // PageDTO type.
 type PageDTO struct {
 	Component      string                      `json:"component"`
@@ -21,6 +23,8 @@ type PageDTO struct {
 type SsrDTO struct {
 	Head []string `json:"head"`
 	Body string   `json:"body"`
+	// RenderedAt is set when the SSR server rendered the body; the cache uses it to detect stale entries.
+	RenderedAt time.Time `json:"renderedAt,omitzero"`
 }
 
 // ScrollPropConfig defines pagination metadata for infinite scroll props.
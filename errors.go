@@ -14,6 +14,10 @@ var (
 	ErrBadSsrStatusCode = errors.New("inertia: bad processSSR status code >= 400")
 	// ErrBaseURLEmpty error.
 	ErrBaseURLEmpty = errors.New("base URL is empty")
+	// ErrSSRCircuitOpen error.
+	ErrSSRCircuitOpen = errors.New("inertia: SSR circuit breaker is open")
+	// ErrSSRDisabled error.
+	ErrSSRDisabled = errors.New("inertia: SSR is disabled")
 )
 
 type ValidationErrors map[string][]string
@@ -161,6 +161,12 @@ func main() {
 			return true
 		}),
 		goinertia.WithSessionStore(sessionAdapter),
+		// render 403/404 of Inertia visits with the Error page instead of redirecting back
+		goinertia.WithErrorPage(goinertia.ErrorPageConfig{
+			Component: "Error",
+			Statuses:  []int{fiber.StatusForbidden, fiber.StatusNotFound},
+			SSR:       true,
+		}),
 		// global props
 		goinertia.WithSharedProps(map[string]any{
 			"menu": menu,
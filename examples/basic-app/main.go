@@ -161,6 +161,11 @@ func main() {
 			// If you need to display errors as they are. true/false
 			return false
 		}),
+		// render 403/404 of Inertia visits with the Error page instead of redirecting back
+		goinertia.WithErrorPage(goinertia.ErrorPageConfig{
+			Component: "Error",
+			Statuses:  []int{fiber.StatusForbidden, fiber.StatusNotFound},
+		}),
 		// global props
 		goinertia.WithSharedProps(map[string]any{
 			"menu": menu,
@@ -0,0 +1,101 @@
+package goinertia
+
+import (
+	"bufio"
+	"context"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// fiberContext adapts fiber.Ctx to requestContext.
+// Per-request state lives in c.Locals.
+type fiberContext struct {
+	c            fiber.Ctx
+	sessionStore SessionStore
+}
+
+func newFiberContext(c fiber.Ctx, sessionStore SessionStore) *fiberContext {
+	return &fiberContext{c: c, sessionStore: sessionStore}
+}
+
+func (i *Inertia) fiberCtx(c fiber.Ctx) *fiberContext {
+	return newFiberContext(c, i.sessionStore)
+}
+
+func (f *fiberContext) RequestContext() context.Context {
+	return f.c
+}
+
+func (f *fiberContext) UserContext() context.Context {
+	return f.c.Context()
+}
+
+func (f *fiberContext) Get(key string) string {
+	return f.c.Get(key)
+}
+
+func (f *fiberContext) Headers() map[string][]string {
+	return f.c.GetHeaders()
+}
+
+func (f *fiberContext) Method() string {
+	return f.c.Method()
+}
+
+func (f *fiberContext) OriginalURL() string {
+	return f.c.OriginalURL()
+}
+
+func (f *fiberContext) BaseURL() string {
+	return f.c.BaseURL()
+}
+
+func (f *fiberContext) Local(key contextKey) any {
+	return f.c.Locals(key)
+}
+
+func (f *fiberContext) SetLocal(key contextKey, value any) {
+	f.c.Locals(key, value)
+}
+
+func (f *fiberContext) Set(key, value string) {
+	f.c.Set(key, value)
+}
+
+func (f *fiberContext) ResponseHeader(key string) string {
+	return string(f.c.Response().Header.Peek(key))
+}
+
+func (f *fiberContext) StatusCode() int {
+	return f.c.Response().StatusCode()
+}
+
+func (f *fiberContext) SetStatus(code int) {
+	f.c.Status(code)
+}
+
+func (f *fiberContext) Send(body []byte) error {
+	return f.c.Send(body)
+}
+
+func (f *fiberContext) SendStream(stream func(w streamWriter) error) error {
+	return f.c.SendStreamWriter(func(w *bufio.Writer) {
+		_ = stream(w)
+	})
+}
+
+func (f *fiberContext) HasSession() bool {
+	return f.sessionStore != nil
+}
+
+func (f *fiberContext) Flash(key string, value any) error {
+	return f.sessionStore.Flash(f.c, key, value)
+}
+
+func (f *fiberContext) GetFlash(key string) (any, error) {
+	return f.sessionStore.GetFlash(f.c, key)
+}
+
+func (f *fiberContext) PeekFlash(key string) (any, error) {
+	return f.sessionStore.Get(f.c, key)
+}
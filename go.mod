@@ -9,6 +9,7 @@ require (
 	github.com/stretchr/testify v1.11.1
 	github.com/valyala/fasthttp v1.69.0
 	go.uber.org/mock v0.6.0
+	golang.org/x/sync v0.19.0
 )
 
 require (
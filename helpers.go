@@ -86,12 +86,12 @@ func appendUnique(list []string, value string) []string {
 	return append(list, value)
 }
 
-func addVaryHeader(c fiber.Ctx, value string) {
+func addVaryHeader(c requestContext, value string) {
 	value = strings.TrimSpace(value)
 	if value == "" {
 		return
 	}
-	current := string(c.Response().Header.Peek("Vary"))
+	current := c.ResponseHeader("Vary")
 	if current == "" {
 		c.Set("Vary", value)
 		return
@@ -108,6 +108,10 @@ func IsPrecognition(c fiber.Ctx) bool {
 	return strings.TrimSpace(c.Get(HeaderPrecognition)) != ""
 }
 
+func isPrecognition(c requestContext) bool {
+	return strings.TrimSpace(c.Get(HeaderPrecognition)) != ""
+}
+
 func parseInertiaBaseURL(baseURL string) *url.URL {
 	base, err := url.Parse(baseURL)
 	if err != nil || base.Scheme == "" || base.Host == "" {
@@ -0,0 +1,399 @@
+package goinertia
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+type httpStateKey struct{}
+
+// httpState holds per-request Inertia state (props, view data, page meta) for net/http.
+// The Middleware stores it in the request context.
+type httpState struct {
+	locals map[contextKey]any
+	// request is the request carrying the state, for providers that only get its context (the CSRF token prop).
+	request *http.Request
+}
+
+// HTTPAdapter exposes Inertia to plain net/http handlers (chi, gorilla/mux, etc.).
+//
+// Example:
+//
+//	adapter := goinertia.NewHTTPAdapter(inertiaManager)
+//	mux.Handle("/", adapter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//		adapter.WithProp(r, "user", user)
+//		if err := adapter.Render(w, r, "Home", map[string]any{"title": "Home"}); err != nil {
+//			http.Error(w, err.Error(), http.StatusInternalServerError)
+//		}
+//	})))
+type HTTPAdapter struct {
+	inertia *Inertia
+}
+
+func NewHTTPAdapter(i *Inertia) *HTTPAdapter {
+	return &HTTPAdapter{inertia: i}
+}
+
+// Middleware handles CSRF checks, asset versioning, flash persistence and redirect status rewriting.
+// It also attaches the per-request state used by the With* helpers, so it must wrap every Inertia handler.
+func (a *HTTPAdapter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := &httpState{locals: make(map[contextKey]any)}
+		r = r.WithContext(context.WithValue(r.Context(), httpStateKey{}, state))
+		state.request = r
+
+		i := a.inertia
+		if i.httpCSRFTokenProvider != nil && i.httpCSRFCheckProvider != nil && i.isMethodPost(r.Method) {
+			if err := i.httpCSRFCheckProvider(r); err != nil {
+				a.csrfError(w, r, err)
+				return
+			}
+		}
+
+		if r.Header.Get(HeaderInertia) != "" &&
+			r.Method == http.MethodGet &&
+			r.Header.Get(HeaderVersion) != a.inertia.currentAssetVersion() &&
+			!isPrecognition(a.context(w, r)) {
+			w.Header().Set(HeaderLocation, buildInertiaLocation(a.inertia.baseURLParsed, r.URL.RequestURI()))
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		next.ServeHTTP(&httpResponseWriter{ResponseWriter: w, adapter: a, request: r}, r)
+	})
+}
+
+// csrfError answers a failed CSRF check with 419, or with the code and message of a returned *Error.
+func (a *HTTPAdapter) csrfError(w http.ResponseWriter, r *http.Request, err error) {
+	rc := a.context(w, r)
+	status, message := 419, a.inertia.message(rc, MessageErrorPageExpired)
+
+	var inertiaErr *Error
+	if errors.As(err, &inertiaErr) && inertiaErr.Code != 0 {
+		status = inertiaErr.Code
+		if inertiaErr.Message != "" {
+			message = a.inertia.translateText(rc, inertiaErr.Message)
+		}
+	}
+	http.Error(w, message, status)
+}
+
+// Render renders the component as JSON for Inertia requests and as the root template otherwise.
+func (a *HTTPAdapter) Render(w http.ResponseWriter, r *http.Request, component string, props map[string]any) error {
+	return a.inertia.render(a.context(w, r), component, props)
+}
+
+func (a *HTTPAdapter) WithProp(r *http.Request, key string, value any) {
+	a.inertia.withProp(a.context(nil, r), key, value)
+}
+
+func (a *HTTPAdapter) WithViewData(r *http.Request, key string, value any) {
+	a.inertia.withViewData(a.context(nil, r), key, value)
+}
+
+// WithFlashMessages adds flashes messages.
+func (a *HTTPAdapter) WithFlashMessages(r *http.Request, flashMessages ...FlashError) {
+	a.inertia.withFlashMessages(a.context(nil, r), flashMessages...)
+}
+
+// WithValidationErrors adds validation errors.
+func (a *HTTPAdapter) WithValidationErrors(r *http.Request, errors ValidationErrors) {
+	a.inertia.withValidationErrors(a.context(nil, r), errors)
+}
+
+// WithErrors adds validation errors to the response.
+func (a *HTTPAdapter) WithErrors(r *http.Request, errors map[string]string) {
+	a.inertia.withErrors(a.context(nil, r), errors)
+}
+
+// WithError adds a single validation error.
+func (a *HTTPAdapter) WithError(r *http.Request, field string, message string) {
+	a.WithErrors(r, map[string]string{
+		field: message,
+	})
+}
+
+// WithFlash adds flash message to the response.
+func (a *HTTPAdapter) WithFlash(r *http.Request, key FlashLevel, message string) {
+	a.inertia.withFlash(a.context(nil, r), key, message)
+}
+
+// WithFlashSuccess adds success flash message.
+func (a *HTTPAdapter) WithFlashSuccess(r *http.Request, message string) {
+	a.WithFlash(r, FlashLevelSuccess, message)
+}
+
+// WithFlashInfo adds info flash message.
+func (a *HTTPAdapter) WithFlashInfo(r *http.Request, message string) {
+	a.WithFlash(r, FlashLevelInfo, message)
+}
+
+// WithFlashWarning adds warning flash message.
+func (a *HTTPAdapter) WithFlashWarning(r *http.Request, message string) {
+	a.WithFlash(r, FlashLevelWarning, message)
+}
+
+// WithFlashError adds error flash message.
+func (a *HTTPAdapter) WithFlashError(r *http.Request, message string) {
+	a.WithFlash(r, FlashLevelError, message)
+}
+
+// WithFlashOld adds old input to the response.
+func (a *HTTPAdapter) WithFlashOld(r *http.Request, data map[string]any) {
+	a.WithProp(r, ContextPropsOld, data)
+}
+
+// WithLazyProp adds a lazy-evaluated prop that's only computed when requested.
+func (a *HTTPAdapter) WithLazyProp(r *http.Request, key string, fn func(context.Context) (any, error)) {
+	a.WithProp(r, key, LazyProp{Key: key, Fn: fn})
+}
+
+// WithMatchPropsOn sets matchPropsOn metadata for the response.
+func (a *HTTPAdapter) WithMatchPropsOn(r *http.Request, props ...string) {
+	a.inertia.withMatchPropsOn(a.context(nil, r), props...)
+}
+
+// WithEncryptHistory sets encryptHistory metadata for the response.
+func (a *HTTPAdapter) WithEncryptHistory(r *http.Request) {
+	a.inertia.withEncryptHistory(a.context(nil, r))
+}
+
+// WithClearHistory sets clearHistory metadata for the response.
+func (a *HTTPAdapter) WithClearHistory(r *http.Request) {
+	a.inertia.withClearHistory(a.context(nil, r))
+}
+
+// WithSSR enables or disables server-side rendering for the response.
+func (a *HTTPAdapter) WithSSR(r *http.Request, enabled bool) {
+	a.inertia.withSSR(a.context(nil, r), enabled)
+}
+
+// RedirectBack redirects back to the referer.
+func (a *HTTPAdapter) RedirectBack(w http.ResponseWriter, r *http.Request) {
+	referer := r.Header.Get("Referer")
+	if referer == "" {
+		referer = r.URL.RequestURI()
+	}
+	a.Redirect(w, r, referer)
+}
+
+// RedirectBackWithErrors redirects back with validation errors stored in session.
+func (a *HTTPAdapter) RedirectBackWithErrors(w http.ResponseWriter, r *http.Request, errors map[string]string) {
+	a.WithErrors(r, errors)
+	a.RedirectBack(w, r)
+}
+
+// RedirectBackWithValidationErrors redirects back with multiple validation errors per field.
+func (a *HTTPAdapter) RedirectBackWithValidationErrors(w http.ResponseWriter, r *http.Request, errors ValidationErrors) {
+	a.WithValidationErrors(r, errors)
+	a.RedirectBack(w, r)
+}
+
+// Redirect handles redirects according to Inertia.js protocol.
+func (a *HTTPAdapter) Redirect(w http.ResponseWriter, r *http.Request, url string) {
+	if url == "" || url == "/" {
+		url = requestBaseURL(r)
+	}
+	if r.Header.Get(HeaderInertia) != "" && a.inertia.isExternalRedirect(url) {
+		a.RedirectExternal(w, r, url)
+		return
+	}
+
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+// RedirectExternal forces a full page reload for Inertia requests.
+func (a *HTTPAdapter) RedirectExternal(w http.ResponseWriter, r *http.Request, url string) {
+	if url == "" || url == "/" {
+		url = requestBaseURL(r)
+	}
+
+	w.Header().Set(HeaderLocation, url)
+	w.Header().Set("Location", url)
+	w.WriteHeader(http.StatusConflict)
+}
+
+func (a *HTTPAdapter) context(w http.ResponseWriter, r *http.Request) *httpContext {
+	state, ok := r.Context().Value(httpStateKey{}).(*httpState)
+	if !ok {
+		// Without the middleware, state only lives for the duration of a single call.
+		state = &httpState{locals: make(map[contextKey]any)}
+	}
+
+	return &httpContext{
+		w:            w,
+		r:            r,
+		state:        state,
+		sessionStore: a.inertia.httpSessionStore,
+	}
+}
+
+// httpResponseWriter applies the Inertia response rules right before the status line is written.
+type httpResponseWriter struct {
+	http.ResponseWriter
+	adapter     *HTTPAdapter
+	request     *http.Request
+	wroteHeader bool
+}
+
+func (w *httpResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.wroteHeader = true
+
+	i := w.adapter.inertia
+	rc := w.adapter.context(w.ResponseWriter, w.request)
+	rc.status = code
+	i.setFlashSessionData(rc)
+
+	if w.request.Header.Get(HeaderInertia) != "" {
+		i.addInertiaResponseHeaders(rc)
+		if i.isMethodPost(w.request.Method) && i.isRedirectStatus(code) {
+			code = http.StatusSeeOther
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *httpResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *httpResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// httpContext adapts net/http to requestContext.
+type httpContext struct {
+	w            http.ResponseWriter
+	r            *http.Request
+	state        *httpState
+	status       int
+	sessionStore HTTPSessionStore
+}
+
+func (h *httpContext) RequestContext() context.Context {
+	return h.r.Context()
+}
+
+func (h *httpContext) UserContext() context.Context {
+	return h.r.Context()
+}
+
+func (h *httpContext) Get(key string) string {
+	return h.r.Header.Get(key)
+}
+
+func (h *httpContext) Headers() map[string][]string {
+	return h.r.Header
+}
+
+func (h *httpContext) Method() string {
+	return h.r.Method
+}
+
+func (h *httpContext) OriginalURL() string {
+	return h.r.URL.RequestURI()
+}
+
+func (h *httpContext) BaseURL() string {
+	return requestBaseURL(h.r)
+}
+
+func (h *httpContext) Local(key contextKey) any {
+	return h.state.locals[key]
+}
+
+func (h *httpContext) SetLocal(key contextKey, value any) {
+	h.state.locals[key] = value
+}
+
+func (h *httpContext) Set(key, value string) {
+	h.w.Header().Set(key, value)
+}
+
+func (h *httpContext) ResponseHeader(key string) string {
+	return h.w.Header().Get(key)
+}
+
+func (h *httpContext) StatusCode() int {
+	if h.status == 0 {
+		return http.StatusOK
+	}
+	return h.status
+}
+
+func (h *httpContext) SetStatus(code int) {
+	h.status = code
+}
+
+func (h *httpContext) Send(body []byte) error {
+	if len(body) > 0 {
+		h.w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	h.w.WriteHeader(h.StatusCode())
+	if len(body) == 0 {
+		return nil
+	}
+	_, err := h.w.Write(body)
+	return err
+}
+
+func (h *httpContext) SendStream(stream func(w streamWriter) error) error {
+	h.w.WriteHeader(h.StatusCode())
+	return stream(&httpStreamWriter{Writer: bufio.NewWriter(h.w), rc: http.NewResponseController(h.w)})
+}
+
+// httpStreamWriter flushes both the buffer and the underlying connection.
+type httpStreamWriter struct {
+	*bufio.Writer
+	rc *http.ResponseController
+}
+
+func (w *httpStreamWriter) Flush() error {
+	if err := w.Writer.Flush(); err != nil {
+		return err
+	}
+	if err := w.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func (h *httpContext) HasSession() bool {
+	return h.sessionStore != nil
+}
+
+func (h *httpContext) Flash(key string, value any) error {
+	return h.sessionStore.Flash(h.r.Context(), key, value)
+}
+
+func (h *httpContext) GetFlash(key string) (any, error) {
+	return h.sessionStore.GetFlash(h.r.Context(), key)
+}
+
+func (h *httpContext) PeekFlash(key string) (any, error) {
+	if getter, ok := h.sessionStore.(HTTPSessionGetter); ok {
+		return getter.Get(h.r.Context(), key)
+	}
+	// PeekFlash is only followed by Flash on the same key, which writes the value back.
+	return h.sessionStore.GetFlash(h.r.Context(), key)
+}
+
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
@@ -0,0 +1,294 @@
+package goinertia_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+	inertiamocks "github.com/assurrussa/goinertia/mocks"
+)
+
+func newHTTPTestAdapter(
+	t *testing.T,
+	handler func(a *goinertia.HTTPAdapter) http.HandlerFunc,
+	opts ...goinertia.Option,
+) http.Handler {
+	t.Helper()
+
+	adapter := goinertia.NewHTTPAdapter(inertiat.NewForTest("http://localhost.loc:3000", opts...))
+	return adapter.Middleware(handler(adapter))
+}
+
+func TestHTTPAdapter_RenderHTML(t *testing.T) {
+	t.Parallel()
+
+	h := newHTTPTestAdapter(t, func(a *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			a.WithViewData(r, "testViewDataKey", "from_context")
+			require.NoError(t, a.Render(w, r, "Home", map[string]any{"title": "Hello"}))
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewRequest(http.MethodGet, "/home?page=2", nil, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), "from_context")
+	assert.Contains(t, rec.Body.String(), "&#34;component&#34;:&#34;Home&#34;")
+	assert.Contains(t, rec.Body.String(), "/home?page=2")
+}
+
+func TestHTTPAdapter_RenderJSON(t *testing.T) {
+	t.Parallel()
+
+	h := newHTTPTestAdapter(t, func(a *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			a.WithProp(r, "user", "john")
+			a.WithFlashSuccess(r, "saved")
+			a.WithLazyProp(r, "lazy", func(context.Context) (any, error) { return "lazy-value", nil })
+			a.WithEncryptHistory(r)
+			require.NoError(t, a.Render(w, r, "Users/Index", map[string]any{
+				"title":  "Users",
+				"stats":  goinertia.Defer("stats"),
+				"filter": goinertia.Optional("all"),
+			}))
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodGet, "/users", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get(goinertia.HeaderInertia))
+	assert.Contains(t, rec.Header().Get("Vary"), goinertia.HeaderInertia)
+
+	page := inertiat.DecodePage(t, rec.Body.String())
+	assert.Equal(t, "Users/Index", page.Component)
+	assert.Equal(t, "/users", page.URL)
+	assert.Equal(t, "v1.0", page.Version)
+	assert.True(t, page.EncryptHistory)
+	assert.Equal(t, "Users", page.Props["title"])
+	assert.Equal(t, "john", page.Props["user"])
+	assert.Equal(t, "lazy-value", page.Props["lazy"])
+	assert.Equal(t, map[string]any{"success": "saved"}, page.Props["flash"])
+	assert.NotContains(t, page.Props, "stats")
+	assert.NotContains(t, page.Props, "filter")
+	assert.Equal(t, map[string][]string{"default": {"stats"}}, page.DeferredProps)
+}
+
+func TestHTTPAdapter_PartialReload(t *testing.T) {
+	t.Parallel()
+
+	h := newHTTPTestAdapter(t, func(a *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, a.Render(w, r, "Users/Index", map[string]any{
+				"title":  "Users",
+				"filter": goinertia.Optional("all"),
+			}))
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodGet, "/users", map[string]string{
+		goinertia.HeaderPartialComponent: "Users/Index",
+		goinertia.HeaderPartialOnly:      "filter",
+	}))
+
+	page := inertiat.DecodePage(t, rec.Body.String())
+	assert.Equal(t, "all", page.Props["filter"])
+	assert.NotContains(t, page.Props, "title")
+}
+
+func TestHTTPAdapter_VersionConflict(t *testing.T) {
+	t.Parallel()
+
+	h := newHTTPTestAdapter(t, func(_ *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(_ http.ResponseWriter, _ *http.Request) {
+			t.Fatal("handler must not be called on version mismatch")
+		}
+	})
+
+	req := inertiat.NewInertiaRequest(http.MethodGet, "/users?page=1", nil)
+	req.Header.Set(goinertia.HeaderVersion, "old")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "http://localhost.loc:3000/users?page=1", rec.Header().Get(goinertia.HeaderLocation))
+}
+
+func TestHTTPAdapter_RedirectSeeOther(t *testing.T) {
+	t.Parallel()
+
+	h := newHTTPTestAdapter(t, func(a *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			a.Redirect(w, r, "/users")
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodPut, "/users/1", nil))
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/users", rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewRequest(http.MethodPut, "/users/1", nil, nil))
+	assert.Equal(t, http.StatusFound, rec.Code)
+}
+
+func TestHTTPAdapter_RedirectExternal(t *testing.T) {
+	t.Parallel()
+
+	h := newHTTPTestAdapter(t, func(a *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			a.Redirect(w, r, "https://example.com/login")
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "https://example.com/login", rec.Header().Get(goinertia.HeaderLocation))
+}
+
+func TestHTTPAdapter_FlashSession(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	store := inertiamocks.NewMockHTTPSessionStore(ctrl)
+
+	var flashed any
+	store.EXPECT().
+		Flash(gomock.Any(), string(goinertia.ContextKeyProps), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, value any) error {
+			flashed = value
+			return nil
+		}).Times(1)
+
+	redirect := newHTTPTestAdapter(t, func(a *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			a.WithFlashError(r, "failed")
+			a.RedirectBackWithErrors(w, r, map[string]string{"name": "required"})
+		}
+	}, goinertia.WithHTTPSessionStore(store))
+
+	rec := httptest.NewRecorder()
+	redirect.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodPost, "/users", map[string]string{
+		"Referer": "/users/create",
+	}))
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/users/create", rec.Header().Get("Location"))
+	require.Equal(t, map[string]any{
+		goinertia.ContextPropsFlash:  map[string]string{"error": "failed"},
+		goinertia.ContextPropsErrors: map[string]string{"name": "required"},
+	}, flashed)
+
+	store.EXPECT().GetFlash(gomock.Any(), string(goinertia.ContextKeyProps)).Return(flashed, nil).Times(1)
+
+	render := newHTTPTestAdapter(t, func(a *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, a.Render(w, r, "Users/Create", nil))
+		}
+	}, goinertia.WithHTTPSessionStore(store))
+
+	rec = httptest.NewRecorder()
+	render.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodGet, "/users/create", nil))
+	page := inertiat.DecodePage(t, rec.Body.String())
+	assert.Equal(t, map[string]any{"error": "failed"}, page.Props["flash"])
+	assert.Equal(t, map[string]any{"name": "required"}, page.Props["errors"])
+}
+
+func TestHTTPAdapter_RenderWithoutMiddleware(t *testing.T) {
+	t.Parallel()
+
+	adapter := goinertia.NewHTTPAdapter(inertiat.NewForTest("http://localhost.loc:3000"))
+
+	rec := httptest.NewRecorder()
+	req := inertiat.NewInertiaRequest(http.MethodGet, "/", nil)
+	adapter.WithProp(req, "lost", true)
+	require.NoError(t, adapter.Render(rec, req, "Home", map[string]any{"title": "Home"}))
+
+	page := inertiat.DecodePage(t, rec.Body.String())
+	assert.Equal(t, "Home", page.Props["title"])
+	assert.NotContains(t, page.Props, "lost")
+}
+
+func TestHTTPAdapter_CSRF(t *testing.T) {
+	t.Parallel()
+
+	const token = "csrf-token-123" //nolint:gosec // tests
+	h := newHTTPTestAdapter(t, func(a *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				a.Redirect(w, r, "/users")
+				return
+			}
+			require.NoError(t, a.Render(w, r, "Users/Create", nil))
+		}
+	},
+		goinertia.WithHTTPCSRFTokenProvider(func(*http.Request) (string, error) { return token, nil }),
+		goinertia.WithHTTPCSRFTokenCheckProvider(func(r *http.Request) error {
+			if r.Header.Get("X-CSRF-Token") != token {
+				return errors.New("token mismatch")
+			}
+			return nil
+		}),
+	)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodGet, "/users/create", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	page := inertiat.DecodePage(t, rec.Body.String())
+	assert.Equal(t, token, page.Props[goinertia.ContextPropsCSRFToken])
+
+	// Partial reloads keep the token.
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodGet, "/users/create", map[string]string{
+		goinertia.HeaderPartialComponent: "Users/Create",
+		goinertia.HeaderPartialOnly:      "user",
+	}))
+	page = inertiat.DecodePage(t, rec.Body.String())
+	assert.Equal(t, token, page.Props[goinertia.ContextPropsCSRFToken])
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodPost, "/users", nil))
+	assert.Equal(t, 419, rec.Code)
+	assert.Empty(t, rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewInertiaRequest(http.MethodPost, "/users", map[string]string{
+		"X-CSRF-Token": token,
+	}))
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/users", rec.Header().Get("Location"))
+}
+
+func TestHTTPAdapter_CSRFCheckError(t *testing.T) {
+	t.Parallel()
+
+	h := newHTTPTestAdapter(t, func(*goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(http.ResponseWriter, *http.Request) {
+			t.Error("handler must not run")
+		}
+	},
+		goinertia.WithHTTPCSRFTokenProvider(func(*http.Request) (string, error) { return "token", nil }),
+		goinertia.WithHTTPCSRFTokenCheckProvider(func(*http.Request) error {
+			return goinertia.NewError(http.StatusForbidden, "Permission denied")
+		}),
+	)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, inertiat.NewRequest(http.MethodDelete, "/users/1", nil, nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Permission denied")
+}
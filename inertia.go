@@ -11,9 +11,11 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v3"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/assurrussa/goinertia/public"
 )
@@ -23,6 +25,10 @@ type pageMeta struct {
 	scrollProps    map[string]ScrollPropConfig
 	encryptHistory *bool
 	clearHistory   *bool
+	ssr            *bool
+	// restoredErrorBags holds the error bags restored from the session, merged with the errors of the request
+	// when the page is built.
+	restoredErrorBags map[string]map[string][]string
 }
 
 type partialConfig struct {
@@ -44,6 +50,8 @@ type Inertia struct {
 	rootHotTemplate           string
 	rootErrorTemplate         string
 	assetVersion              string
+	assetVersionSource        *assetVersionSource
+	assetVersionErr           error
 	sharedProps               map[string]any
 	sharedFuncMap             template.FuncMap
 	sharedViewData            map[string]any
@@ -55,19 +63,44 @@ type Inertia struct {
 	parsedErrorTemplateErr    error
 	hotURL                    string
 	hotURLOnce                sync.Once
+	viteManifestPath          string
+	viteManifest              viteManifest
+	viteManifestMu            sync.Mutex
+	viteManifestLoaded        bool
 	templateFS                fs.FS
 	publicFS                  fs.ReadFileFS
 	ssrConfig                 SSRConfig
 	ssrClient                 SSRClient
-	ssrCache                  *ssrCache
+	ssrCache                  SSRCache
+	ssrCacheHits              atomic.Uint64
+	ssrCacheMisses            atomic.Uint64
+	ssrCacheStaleHits         atomic.Uint64
+	ssrCacheVersion           atomic.Pointer[string]
+	ssrFlights                singleflight.Group
+	ssrBreaker                *ssrBreaker
+	ssrPool                   *ssrPool
+	ssrProcess                *ssrProcess
+	ssrProcessMu              sync.Mutex
+	ssrHealth                 ssrHealth
+	ssrHealthClientOnce       sync.Once
+	ssrHealthFallback         SSRHealthClient
 	sessionStore              SessionStore // Adds session support.
+	httpSessionStore          HTTPSessionStore
 	logger                    Logger
 	canExposeDetails          func(ctx context.Context, headers map[string][]string) bool
 	customErrorDetailsHandler func(errReturn *Error, isCanDetails bool) string
 	customErrorGettingHandler func(err error) *Error
+	errorPage                 ErrorPageConfig
+	oldInput                  *OldInputConfig
+	translator                Translator
+	localeResolver            func(ctx context.Context, headers map[string][]string) string
 	csrfTokenCheckProvider    CSRFTokenCheckProvider
 	csrfTokenProvider         CSRFTokenProvider
+	httpCSRFCheckProvider     HTTPCSRFTokenCheckProvider
+	httpCSRFTokenProvider     HTTPCSRFTokenProvider
 	csrfPropName              string
+	fullValidationErrors      bool
+	validator                 Validator
 	isDev                     bool
 	precognitionVary          bool
 }
@@ -85,6 +118,9 @@ func NewWithValidation(baseURL string, opts ...Option) (*Inertia, error) {
 	if inr.baseURLParsed == nil {
 		return nil, ErrBaseURLEmpty
 	}
+	if inr.assetVersionErr != nil {
+		return nil, fmt.Errorf("failed to compute asset version: %w", inr.assetVersionErr)
+	}
 	if err := inr.ParseTemplates(); err != nil {
 		return nil, fmt.Errorf("failed to parse templates: %w", err)
 	}
@@ -121,10 +157,10 @@ func New(baseURL string, opts ...Option) *Inertia {
 		sharedProps:       make(map[string]any),
 		parsedTemplate:    nil,
 		logger:            NewLoggerAdapter(nil),
+		viteManifestPath:  DefaultViteManifestPath,
 		sharedFuncMap: template.FuncMap{
 			"marshal": marshal,
 			"raw":     raw,
-			"asset":   asset,
 		},
 		sharedViewData:            make(map[string]any),
 		canExposeDetails:          DefaultCanExpose,
@@ -133,6 +169,8 @@ func New(baseURL string, opts ...Option) *Inertia {
 		csrfPropName:              ContextPropsCSRFToken,
 		precognitionVary:          true,
 	}
+	inr.sharedFuncMap["asset"] = inr.viteAsset
+	inr.sharedFuncMap["vite"] = inr.viteTags
 
 	for _, o := range opts {
 		o(inr)
@@ -150,6 +188,12 @@ func New(baseURL string, opts ...Option) *Inertia {
 		inr.rootErrorTemplate = "error.gohtml"
 	}
 
+	if inr.viteManifestPath == "" {
+		inr.viteManifestPath = DefaultViteManifestPath
+	}
+
+	inr.initAssetVersion()
+
 	inr.baseURLParsed = parseInertiaBaseURL(inr.baseURL)
 	if inr.baseURLParsed != nil {
 		inr.baseURL = inr.baseURLParsed.String()
@@ -177,60 +221,27 @@ func (i *Inertia) ParseTemplates() error {
 }
 
 func (i *Inertia) WithProp(c fiber.Ctx, key string, value any) {
-	props := i.getContextKeyProps(c)
-
-	props[key] = value
-	c.Locals(ContextKeyProps, props)
+	i.withProp(i.fiberCtx(c), key, value)
 }
 
 func (i *Inertia) WithViewData(c fiber.Ctx, key string, value any) {
-	data := i.getContextKeyViewData(c)
-
-	data[key] = value
-	c.Locals(ContextKeyViewData, data)
+	i.withViewData(i.fiberCtx(c), key, value)
 }
 
 // WithFlashMessages adds flashes messages.
 func (i *Inertia) WithFlashMessages(c fiber.Ctx, flashMessages ...FlashError) {
-	if len(flashMessages) == 0 {
-		return
-	}
-
-	for _, fm := range flashMessages {
-		i.WithFlash(c, fm.Level, fm.Error())
-	}
+	i.withFlashMessages(i.fiberCtx(c), flashMessages...)
 }
 
 // WithValidationErrors adds validation errors (equivalent to Django's form validation).
 func (i *Inertia) WithValidationErrors(c fiber.Ctx, errors ValidationErrors) {
-	if len(errors) == 0 {
-		return
-	}
-
-	flatErrors := make(map[string]string)
-	for field, fieldErrors := range errors {
-		if len(fieldErrors) > 0 {
-			flatErrors[field] = fieldErrors[0] // Take first error
-		}
-	}
-	i.WithErrors(c, flatErrors)
+	i.withValidationErrors(i.fiberCtx(c), errors)
 }
 
 // WithErrors adds validation errors to the response.
 // Only adds to context; session is written via setFlashSessionData.
 func (i *Inertia) WithErrors(c fiber.Ctx, errors map[string]string) {
-	props := i.getContextKeyProps(c)
-
-	curErrors := make(map[string]string)
-	if existingErrors, exists := props[ContextPropsErrors].(map[string]string); exists {
-		curErrors = existingErrors
-	}
-
-	for field, message := range errors {
-		curErrors[field] = message
-	}
-
-	i.WithProp(c, ContextPropsErrors, curErrors)
+	i.withErrors(i.fiberCtx(c), errors)
 }
 
 // WithError adds a single validation error.
@@ -269,16 +280,7 @@ func (i *Inertia) WithFlashOld(c fiber.Ctx, data map[string]any) {
 // WithFlash adds flash message to the response.
 // Only adds to context; session is written via setFlashSessionData.
 func (i *Inertia) WithFlash(c fiber.Ctx, key FlashLevel, message string) {
-	props := i.getContextKeyProps(c)
-
-	flash := make(map[string]string)
-	if existingFlash, exists := props[ContextPropsFlash].(map[string]string); exists {
-		flash = existingFlash
-	}
-
-	flash[key.String()] = message
-	props[ContextPropsFlash] = flash
-	c.Locals(ContextKeyProps, props)
+	i.withFlash(i.fiberCtx(c), key, message)
 }
 
 // WithLazyProp adds a lazy-evaluated prop that's only computed when requested.
@@ -288,30 +290,23 @@ func (i *Inertia) WithLazyProp(c fiber.Ctx, key string, fn func(context.Context)
 
 // WithMatchPropsOn sets matchPropsOn metadata for the response.
 func (i *Inertia) WithMatchPropsOn(c fiber.Ctx, props ...string) {
-	if len(props) == 0 {
-		return
-	}
-	meta := i.getContextKeyPageMeta(c)
-	for _, prop := range props {
-		if prop == "" {
-			continue
-		}
-		meta.matchPropsOn = appendUnique(meta.matchPropsOn, prop)
-	}
+	i.withMatchPropsOn(i.fiberCtx(c), props...)
 }
 
 // WithEncryptHistory sets encryptHistory metadata for the response.
 func (i *Inertia) WithEncryptHistory(c fiber.Ctx) {
-	meta := i.getContextKeyPageMeta(c)
-	value := true
-	meta.encryptHistory = &value
+	i.withEncryptHistory(i.fiberCtx(c))
 }
 
 // WithClearHistory sets clearHistory metadata for the response.
 func (i *Inertia) WithClearHistory(c fiber.Ctx) {
-	meta := i.getContextKeyPageMeta(c)
-	value := true
-	meta.clearHistory = &value
+	i.withClearHistory(i.fiberCtx(c))
+}
+
+// WithSSR enables or disables server-side rendering for the response, overriding the component patterns
+// of SSRConfig. It has no effect while SSR is disabled.
+func (i *Inertia) WithSSR(c fiber.Ctx, enabled bool) {
+	i.withSSR(i.fiberCtx(c), enabled)
 }
 
 // RedirectBackWithValidationErrors redirects back with multiple validation errors per field.
@@ -363,11 +358,137 @@ func (i *Inertia) RedirectExternal(c fiber.Ctx, url string) error {
 	return c.SendStatus(fiber.StatusConflict)
 }
 
-func (i *Inertia) isPrecognitionRequest(c fiber.Ctx) bool {
-	return IsPrecognition(c)
+func (i *Inertia) Render(c fiber.Ctx, component string, props map[string]any) error {
+	return i.render(i.fiberCtx(c), component, props)
+}
+
+func (i *Inertia) withProp(c requestContext, key string, value any) {
+	props := i.getContextKeyProps(c)
+
+	props[key] = value
+	c.SetLocal(ContextKeyProps, props)
+}
+
+func (i *Inertia) withViewData(c requestContext, key string, value any) {
+	data := i.getContextKeyViewData(c)
+
+	data[key] = value
+	c.SetLocal(ContextKeyViewData, data)
+}
+
+func (i *Inertia) withFlashMessages(c requestContext, flashMessages ...FlashError) {
+	if len(flashMessages) == 0 {
+		return
+	}
+
+	for _, fm := range flashMessages {
+		i.withFlash(c, fm.Level, fm.Error())
+	}
+}
+
+func (i *Inertia) withValidationErrors(c requestContext, errors ValidationErrors) {
+	if len(errors) == 0 {
+		return
+	}
+	if i.fullValidationErrors {
+		i.mergeValidationErrorsProp(c, errors)
+		return
+	}
+
+	flatErrors := make(map[string]string)
+	for field, fieldErrors := range errors {
+		if len(fieldErrors) > 0 {
+			flatErrors[field] = fieldErrors[0] // Take first error
+		}
+	}
+	i.withErrors(c, flatErrors)
+}
+
+func (i *Inertia) withErrors(c requestContext, errors map[string]string) {
+	if i.fullValidationErrors {
+		i.mergeValidationErrorsProp(c, validationErrorsFromMapStringString(errors))
+		return
+	}
+
+	props := i.getContextKeyProps(c)
+
+	curErrors := make(map[string]string)
+	if existingErrors, exists := props[ContextPropsErrors].(map[string]string); exists {
+		curErrors = existingErrors
+	}
+
+	for field, message := range errors {
+		curErrors[field] = message
+	}
+
+	i.withProp(c, ContextPropsErrors, curErrors)
 }
 
-func (i *Inertia) shouldNoCacheResponse(c fiber.Ctx) bool {
+// mergeValidationErrorsProp keeps every message per field in the errors prop (see WithFullValidationErrors).
+func (i *Inertia) mergeValidationErrorsProp(c requestContext, errors ValidationErrors) {
+	props := i.getContextKeyProps(c)
+
+	curErrors := normalizeValidationErrors(props[ContextPropsErrors])
+	if curErrors == nil {
+		curErrors = make(ValidationErrors, len(errors))
+	}
+	for field, messages := range errors {
+		if len(messages) > 0 {
+			curErrors[field] = append([]string{}, messages...)
+		}
+	}
+
+	i.withProp(c, ContextPropsErrors, curErrors)
+}
+
+func (i *Inertia) withFlash(c requestContext, key FlashLevel, message string) {
+	props := i.getContextKeyProps(c)
+
+	flash := make(map[string]string)
+	if existingFlash, exists := props[ContextPropsFlash].(map[string]string); exists {
+		flash = existingFlash
+	}
+
+	flash[key.String()] = message
+	props[ContextPropsFlash] = flash
+	c.SetLocal(ContextKeyProps, props)
+}
+
+func (i *Inertia) withMatchPropsOn(c requestContext, props ...string) {
+	if len(props) == 0 {
+		return
+	}
+	meta := i.getContextKeyPageMeta(c)
+	for _, prop := range props {
+		if prop == "" {
+			continue
+		}
+		meta.matchPropsOn = appendUnique(meta.matchPropsOn, prop)
+	}
+}
+
+func (i *Inertia) withEncryptHistory(c requestContext) {
+	meta := i.getContextKeyPageMeta(c)
+	value := true
+	meta.encryptHistory = &value
+}
+
+func (i *Inertia) withClearHistory(c requestContext) {
+	meta := i.getContextKeyPageMeta(c)
+	value := true
+	meta.clearHistory = &value
+}
+
+func (i *Inertia) withSSR(c requestContext, enabled bool) {
+	meta := i.getContextKeyPageMeta(c)
+	meta.ssr = &enabled
+}
+
+func (i *Inertia) isPrecognitionRequest(c requestContext) bool {
+	return isPrecognition(c)
+}
+
+func (i *Inertia) shouldNoCacheResponse(c requestContext) bool {
 	cacheControl := strings.ToLower(strings.TrimSpace(c.Get(fiber.HeaderCacheControl)))
 	return cacheControl != "" && strings.Contains(cacheControl, "no-cache")
 }
@@ -388,12 +509,12 @@ func filterValidationErrors(errors ValidationErrors, only map[string]struct{}) V
 	return filtered
 }
 
-func (i *Inertia) collectPrecognitionErrors(c fiber.Ctx) ValidationErrors {
+func (i *Inertia) collectPrecognitionErrors(c requestContext) ValidationErrors {
 	props := i.getContextKeyProps(c)
 	return normalizeValidationErrors(props[ContextPropsErrors])
 }
 
-func (i *Inertia) renderPrecognition(c fiber.Ctx, errors ValidationErrors) error {
+func (i *Inertia) renderPrecognition(c requestContext, errors ValidationErrors) error {
 	addVaryHeader(c, HeaderPrecognition)
 	c.Set(HeaderPrecognition, "true")
 	if i.shouldNoCacheResponse(c) {
@@ -402,7 +523,8 @@ func (i *Inertia) renderPrecognition(c fiber.Ctx, errors ValidationErrors) error
 
 	if len(errors) == 0 {
 		c.Set(HeaderPrecognitionSuccess, "true")
-		return c.SendStatus(fiber.StatusNoContent)
+		c.SetStatus(fiber.StatusNoContent)
+		return c.Send(nil)
 	}
 
 	payload := map[string]any{"errors": errors}
@@ -411,16 +533,15 @@ func (i *Inertia) renderPrecognition(c fiber.Ctx, errors ValidationErrors) error
 		return fmt.Errorf("error marshaling precognition errors: %w", err)
 	}
 
-	c.Status(fiber.StatusUnprocessableEntity)
+	c.SetStatus(fiber.StatusUnprocessableEntity)
 	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
 	return c.Send(js)
 }
 
-func (i *Inertia) renderPrecognitionError(c fiber.Ctx, errReturn *Error) error {
-	errors := errReturn.ValidationErrors()
-	errors = filterValidationErrors(errors, parseHeaderList(c.Get(HeaderPrecognitionValidateOnly)))
-	if len(errors) > 0 {
-		return i.renderPrecognition(c, errors)
+func (i *Inertia) renderPrecognitionError(c requestContext, errReturn *Error) error {
+	// A validation error means the requested fields were validated: the ones left after filtering failed.
+	if errors := errReturn.ValidationErrors(); errors != nil {
+		return i.renderPrecognition(c, filterValidationErrors(errors, parseHeaderList(c.Get(HeaderPrecognitionValidateOnly))))
 	}
 
 	addVaryHeader(c, HeaderPrecognition)
@@ -440,18 +561,18 @@ func (i *Inertia) renderPrecognitionError(c fiber.Ctx, errReturn *Error) error {
 		}
 	}
 
-	payload := map[string]any{"message": message}
+	payload := map[string]any{"message": i.translateText(c, message)}
 	js, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("error marshaling precognition error: %w", err)
 	}
 
-	c.Status(status)
+	c.SetStatus(status)
 	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
 	return c.Send(js)
 }
 
-func (i *Inertia) Render(c fiber.Ctx, component string, props map[string]any) error {
+func (i *Inertia) render(c requestContext, component string, props map[string]any) error {
 	if i.isPrecognitionRequest(c) {
 		errors := i.collectPrecognitionErrors(c)
 		errors = filterValidationErrors(errors, parseHeaderList(c.Get(HeaderPrecognitionValidateOnly)))
@@ -471,18 +592,18 @@ func (i *Inertia) Render(c fiber.Ctx, component string, props map[string]any) er
 }
 
 // getContextKeyProps returns existing props or creates new ones.
-func (i *Inertia) getContextKeyProps(c fiber.Ctx) map[string]any {
+func (i *Inertia) getContextKeyProps(c requestContext) map[string]any {
 	return i.getContextKey(c, ContextKeyProps)
 }
 
 // getContextKeyViewData returns existing views or creates new ones.
-func (i *Inertia) getContextKeyViewData(c fiber.Ctx) map[string]any {
+func (i *Inertia) getContextKeyViewData(c requestContext) map[string]any {
 	return i.getContextKey(c, ContextKeyViewData)
 }
 
 // getContextKeyPageMeta returns existing page meta or creates new one.
-func (i *Inertia) getContextKeyPageMeta(c fiber.Ctx) *pageMeta {
-	meta := c.Locals(ContextKeyPageMeta)
+func (i *Inertia) getContextKeyPageMeta(c requestContext) *pageMeta {
+	meta := c.Local(ContextKeyPageMeta)
 	if meta != nil {
 		if pm, ok := meta.(*pageMeta); ok {
 			return pm
@@ -492,13 +613,13 @@ func (i *Inertia) getContextKeyPageMeta(c fiber.Ctx) *pageMeta {
 	pm := &pageMeta{
 		scrollProps: make(map[string]ScrollPropConfig),
 	}
-	c.Locals(ContextKeyPageMeta, pm)
+	c.SetLocal(ContextKeyPageMeta, pm)
 	return pm
 }
 
 // getContextKey returns existing values by key or creates new ones.
-func (i *Inertia) getContextKey(c fiber.Ctx, key contextKey) map[string]any {
-	ctxData := c.Locals(key)
+func (i *Inertia) getContextKey(c requestContext, key contextKey) map[string]any {
+	ctxData := c.Local(key)
 	data := make(map[string]any)
 	if ctxData != nil {
 		if p, ok := ctxData.(map[string]any); ok {
@@ -510,14 +631,14 @@ func (i *Inertia) getContextKey(c fiber.Ctx, key contextKey) map[string]any {
 }
 
 // buildPage constructs the page data with props from various sources.
-func (i *Inertia) buildPage(c fiber.Ctx, component string, props map[string]any) (*PageDTO, error) {
+func (i *Inertia) buildPage(c requestContext, component string, props map[string]any) (*PageDTO, error) {
 	partial := i.parsePartialConfig(c, component)
 
 	page := &PageDTO{
 		Component: component,
 		Props:     make(map[string]any),
 		URL:       c.OriginalURL(),
-		Version:   i.assetVersion,
+		Version:   i.currentAssetVersion(),
 	}
 
 	// Add props in order: shared -> context -> request
@@ -537,7 +658,7 @@ func (i *Inertia) buildPage(c fiber.Ctx, component string, props map[string]any)
 }
 
 // parsePartialConfig extracts partial reload configuration.
-func (i *Inertia) parsePartialConfig(c fiber.Ctx, component string) *partialConfig {
+func (i *Inertia) parsePartialConfig(c requestContext, component string) *partialConfig {
 	cfg := &partialConfig{
 		reset:      parseHeaderList(c.Get(HeaderReset)),
 		exceptOnce: parseHeaderList(c.Get(HeaderExceptOnceProps)),
@@ -564,7 +685,7 @@ func (i *Inertia) parsePartialConfig(c fiber.Ctx, component string) *partialConf
 			ContextPropsOld:    {},
 			ContextPropsErrors: {},
 		}
-		if i.csrfPropName != "" && i.csrfTokenProvider != nil {
+		if i.csrfPropName != "" && i.hasCSRFTokenProvider() {
 			cfg.forceInclude[i.csrfPropName] = struct{}{}
 		}
 	}
@@ -649,8 +770,8 @@ func parseHeaderList(value string) map[string]struct{} {
 // setFlashSessionData persists flash-related props (flash/errors/old) into the session.
 // It is only needed for redirect-like responses (3xx or 409 with X-Inertia-Location),
 // so we skip it for normal renders and for Precognition requests.
-func (i *Inertia) setFlashSessionData(c fiber.Ctx) {
-	if i.sessionStore == nil {
+func (i *Inertia) setFlashSessionData(c requestContext) {
+	if !c.HasSession() {
 		return
 	}
 
@@ -659,13 +780,13 @@ func (i *Inertia) setFlashSessionData(c fiber.Ctx) {
 		return
 	}
 
-	status := c.Response().StatusCode()
+	status := c.StatusCode()
 	isRedirect := status == fiber.StatusMovedPermanently ||
 		status == fiber.StatusFound ||
 		status == fiber.StatusSeeOther ||
 		status == fiber.StatusTemporaryRedirect ||
 		status == fiber.StatusPermanentRedirect
-	isInertiaLocationConflict := status == fiber.StatusConflict && len(c.Response().Header.Peek(HeaderLocation)) > 0
+	isInertiaLocationConflict := status == fiber.StatusConflict && c.ResponseHeader(HeaderLocation) != ""
 	if !isRedirect && !isInertiaLocationConflict {
 		return
 	}
@@ -680,8 +801,13 @@ func (i *Inertia) setFlashSessionData(c fiber.Ctx) {
 	if data, ok := props[ContextPropsFlash].(map[string]string); ok && len(data) > 0 {
 		flashData[ContextPropsFlash] = data
 	}
-	if data, ok := props[ContextPropsErrors].(map[string]string); ok && len(data) > 0 {
-		flashData[ContextPropsErrors] = data
+	if bag := strings.TrimSpace(c.Get(HeaderErrorBag)); bag != "" {
+		// The bag of the submitting form is stored with its errors, so the next page restores errors.{bag}.
+		if errors := normalizeValidationErrors(props[ContextPropsErrors]); len(errors) > 0 {
+			flashData[flashKeyErrorBags] = i.pendingErrorBags(c, bag, errors)
+		}
+	} else {
+		i.addFlashErrors(flashData, props[ContextPropsErrors])
 	}
 	if data, ok := props[ContextPropsOld].(map[string]any); ok && len(data) > 0 {
 		flashData[ContextPropsOld] = data
@@ -689,19 +815,60 @@ func (i *Inertia) setFlashSessionData(c fiber.Ctx) {
 	if len(flashData) == 0 {
 		return
 	}
+	if err := c.Flash(string(ContextKeyProps), flashData); err != nil {
+		i.logger.ErrorContext(c.RequestContext(), "could not set flash session props", "error", err)
+	}
+}
+
+// addFlashErrors adds the errors prop to the flashed data.
+func (i *Inertia) addFlashErrors(flashData map[string]any, errors any) {
+	switch data := errors.(type) {
+	case map[string]string:
+		if len(data) > 0 {
+			flashData[ContextPropsErrors] = data
+		}
+	case ValidationErrors:
+		// Stored as the plain map type, which is registered with gob.
+		if len(data) > 0 {
+			flashData[ContextPropsErrors] = map[string][]string(data)
+		}
+	}
+}
 
-	if err := i.sessionStore.Flash(c, string(ContextKeyProps), flashData); err != nil {
-		i.logger.ErrorContext(c, "could not set flash session props", "error", err)
+// pendingErrorBags returns the errors of the bag together with the bags flashed by earlier requests
+// that no page has shown yet, so the errors of several forms coexist.
+func (i *Inertia) pendingErrorBags(c requestContext, bag string, errors ValidationErrors) map[string]map[string][]string {
+	bags := map[string]map[string][]string{bag: errors}
+	for name, bagErrors := range i.flashedErrorBags(c) {
+		if _, exists := bags[name]; !exists {
+			bags[name] = bagErrors
+		}
 	}
+	return bags
+}
+
+// flashedErrorBags returns the error bags flashed by earlier requests that no page has shown yet,
+// leaving the flash in the session.
+func (i *Inertia) flashedErrorBags(c requestContext) map[string]map[string][]string {
+	pending, err := c.PeekFlash(string(ContextKeyProps))
+	if err != nil {
+		return nil
+	}
+	data, ok := pending.(map[string]any)
+	if !ok {
+		return nil
+	}
+	bags, _ := data[flashKeyErrorBags].(map[string]map[string][]string)
+	return bags
 }
 
 // loadFlashSessionData loads flash data from session storage.
-func (i *Inertia) loadFlashSessionData(c fiber.Ctx, page *PageDTO, partial *partialConfig) {
-	if i.sessionStore == nil {
+func (i *Inertia) loadFlashSessionData(c requestContext, page *PageDTO, partial *partialConfig) {
+	if !c.HasSession() {
 		return
 	}
 
-	flashRaw, err := i.sessionStore.GetFlash(c, string(ContextKeyProps))
+	flashRaw, err := c.GetFlash(string(ContextKeyProps))
 	if err != nil {
 		return
 	}
@@ -715,8 +882,20 @@ func (i *Inertia) loadFlashSessionData(c fiber.Ctx, page *PageDTO, partial *part
 		i.setPropValue(c, page, ContextPropsFlash, data, partial)
 	}
 
-	if data, ok := flashData[ContextPropsErrors].(map[string]string); ok && len(data) > 0 {
-		i.setPropValue(c, page, ContextPropsErrors, data, partial)
+	switch data := flashData[ContextPropsErrors].(type) {
+	case map[string]string:
+		if len(data) > 0 {
+			i.setPropValue(c, page, ContextPropsErrors, data, partial)
+		}
+	case map[string][]string:
+		if len(data) > 0 {
+			i.setPropValue(c, page, ContextPropsErrors, i.restoreValidationErrors(data), partial)
+		}
+	}
+
+	if bags, ok := flashData[flashKeyErrorBags].(map[string]map[string][]string); ok && len(bags) > 0 {
+		// Merged with the other errors of the page by applyErrorBag.
+		i.getContextKeyPageMeta(c).restoredErrorBags = bags
 	}
 
 	if data, ok := flashData[ContextPropsOld].(map[string]any); ok && len(data) > 0 {
@@ -725,7 +904,7 @@ func (i *Inertia) loadFlashSessionData(c fiber.Ctx, page *PageDTO, partial *part
 }
 
 // addContextProps adds context-specific props to the page.
-func (i *Inertia) addContextProps(c fiber.Ctx, page *PageDTO, partial *partialConfig) error {
+func (i *Inertia) addContextProps(c requestContext, page *PageDTO, partial *partialConfig) error {
 	// Load flash data from the session first.
 	i.loadFlashSessionData(c, page, partial)
 
@@ -734,7 +913,7 @@ func (i *Inertia) addContextProps(c fiber.Ctx, page *PageDTO, partial *partialCo
 }
 
 // addSharedProps adds shared props to the page.
-func (i *Inertia) addSharedProps(c fiber.Ctx, page *PageDTO, partial *partialConfig, overrideKeys map[string]struct{}) {
+func (i *Inertia) addSharedProps(c requestContext, page *PageDTO, partial *partialConfig, overrideKeys map[string]struct{}) {
 	if len(overrideKeys) == 0 {
 		i.addRequestProps(c, page, i.sharedProps, partial)
 		return
@@ -751,20 +930,20 @@ func (i *Inertia) addSharedProps(c fiber.Ctx, page *PageDTO, partial *partialCon
 }
 
 // addLocalContextProps adds local context props to the page.
-func (i *Inertia) addLocalContextProps(c fiber.Ctx, page *PageDTO, partial *partialConfig) error {
+func (i *Inertia) addLocalContextProps(c requestContext, page *PageDTO, partial *partialConfig) error {
 	props := i.getContextKeyProps(c)
 	i.addRequestProps(c, page, props, partial)
 	return nil
 }
 
 // addRequestProps adds request-specific props to the page.
-func (i *Inertia) addRequestProps(c fiber.Ctx, page *PageDTO, props map[string]any, partial *partialConfig) {
+func (i *Inertia) addRequestProps(c requestContext, page *PageDTO, props map[string]any, partial *partialConfig) {
 	for key, value := range props {
 		i.setPropValue(c, page, key, value, partial)
 	}
 }
 
-func (i *Inertia) collectOverrideKeys(c fiber.Ctx, props map[string]any) map[string]struct{} {
+func (i *Inertia) collectOverrideKeys(c requestContext, props map[string]any) map[string]struct{} {
 	override := make(map[string]struct{})
 
 	for key := range props {
@@ -780,7 +959,7 @@ func (i *Inertia) collectOverrideKeys(c fiber.Ctx, props map[string]any) map[str
 }
 
 func (i *Inertia) registerCSRFSharedProp() {
-	if i.csrfTokenProvider == nil {
+	if !i.hasCSRFTokenProvider() {
 		if i.csrfPropName != "" {
 			delete(i.sharedProps, i.csrfPropName)
 		}
@@ -795,12 +974,7 @@ func (i *Inertia) registerCSRFSharedProp() {
 	i.sharedProps[propName] = LazyProp{
 		Key: propName,
 		Fn: func(ctx context.Context) (any, error) {
-			fiberCtx, ok := ctx.(fiber.Ctx)
-			if !ok {
-				return "", nil
-			}
-
-			token, err := i.csrfTokenProvider(fiberCtx)
+			token, err := i.csrfToken(ctx)
 			if err != nil {
 				return "", err
 			}
@@ -810,6 +984,25 @@ func (i *Inertia) registerCSRFSharedProp() {
 	}
 }
 
+func (i *Inertia) hasCSRFTokenProvider() bool {
+	return i.csrfTokenProvider != nil || i.httpCSRFTokenProvider != nil
+}
+
+// csrfToken returns the token of the provider matching the transport of the request context:
+// the fiber.Ctx itself, or the context of a request that went through HTTPAdapter.Middleware.
+func (i *Inertia) csrfToken(ctx context.Context) (string, error) {
+	if fiberCtx, ok := ctx.(fiber.Ctx); ok {
+		if i.csrfTokenProvider == nil {
+			return "", nil
+		}
+		return i.csrfTokenProvider(fiberCtx)
+	}
+	if state, ok := ctx.Value(httpStateKey{}).(*httpState); ok && state.request != nil && i.httpCSRFTokenProvider != nil {
+		return i.httpCSRFTokenProvider(state.request)
+	}
+	return "", nil
+}
+
 // shouldIncludeProp determines if a prop should be included based on partial reload config.
 func (i *Inertia) shouldIncludeProp(key string, partial *partialConfig) bool {
 	if partial == nil {
@@ -819,7 +1012,7 @@ func (i *Inertia) shouldIncludeProp(key string, partial *partialConfig) bool {
 }
 
 // setPropValue sets a prop value, handling lazy props appropriately.
-func (i *Inertia) setPropValue(c fiber.Ctx, page *PageDTO, key string, value any, partial *partialConfig) {
+func (i *Inertia) setPropValue(c requestContext, page *PageDTO, key string, value any, partial *partialConfig) {
 	if value == nil {
 		i.setNilProp(page, key, partial)
 		return
@@ -848,7 +1041,7 @@ func (i *Inertia) setPropValue(c fiber.Ctx, page *PageDTO, key string, value any
 
 	result, err := i.resolvePropValue(c, key, value)
 	if err != nil {
-		i.logger.WarnContext(c, "failed to evaluate prop", "key", key, "error", err)
+		i.logger.WarnContext(c.RequestContext(), "failed to evaluate prop", "key", key, "error", err)
 		return
 	}
 
@@ -881,7 +1074,7 @@ func (i *Inertia) applyOnceProp(page *PageDTO, key string, op OnceProp, partial
 	return op.Value, false
 }
 
-func (i *Inertia) handleWrappedProp(c fiber.Ctx, page *PageDTO, key string, value any, partial *partialConfig) bool {
+func (i *Inertia) handleWrappedProp(c requestContext, page *PageDTO, key string, value any, partial *partialConfig) bool {
 	switch prop := value.(type) {
 	case DeferredProp:
 		return i.handleDeferredProp(c, page, key, prop, partial)
@@ -898,7 +1091,7 @@ func (i *Inertia) handleWrappedProp(c fiber.Ctx, page *PageDTO, key string, valu
 	}
 }
 
-func (i *Inertia) handleDeferredProp(c fiber.Ctx, page *PageDTO, key string, prop DeferredProp, partial *partialConfig) bool {
+func (i *Inertia) handleDeferredProp(c requestContext, page *PageDTO, key string, prop DeferredProp, partial *partialConfig) bool {
 	if partial != nil && partial.explicitlyIncluded(key) {
 		i.setPropValue(c, page, key, prop.Value, partial)
 		return true
@@ -915,7 +1108,7 @@ func (i *Inertia) handleDeferredProp(c fiber.Ctx, page *PageDTO, key string, pro
 	return true
 }
 
-func (i *Inertia) handleOptionalProp(c fiber.Ctx, page *PageDTO, key string, prop OptionalProp, partial *partialConfig) bool {
+func (i *Inertia) handleOptionalProp(c requestContext, page *PageDTO, key string, prop OptionalProp, partial *partialConfig) bool {
 	if partial == nil || !partial.explicitlyIncluded(key) {
 		return true
 	}
@@ -923,7 +1116,7 @@ func (i *Inertia) handleOptionalProp(c fiber.Ctx, page *PageDTO, key string, pro
 	return true
 }
 
-func (i *Inertia) handleAlwaysProp(c fiber.Ctx, page *PageDTO, key string, prop AlwaysProp, partial *partialConfig) bool {
+func (i *Inertia) handleAlwaysProp(c requestContext, page *PageDTO, key string, prop AlwaysProp, partial *partialConfig) bool {
 	if partial != nil {
 		if partial.forceInclude == nil {
 			partial.forceInclude = make(map[string]struct{})
@@ -934,7 +1127,7 @@ func (i *Inertia) handleAlwaysProp(c fiber.Ctx, page *PageDTO, key string, prop
 	return true
 }
 
-func (i *Inertia) handleMergeProp(c fiber.Ctx, page *PageDTO, key string, prop MergeProp, partial *partialConfig) bool {
+func (i *Inertia) handleMergeProp(c requestContext, page *PageDTO, key string, prop MergeProp, partial *partialConfig) bool {
 	if partial == nil || !partial.isReset(key) {
 		switch {
 		case prop.Prepend:
@@ -952,7 +1145,7 @@ func (i *Inertia) handleMergeProp(c fiber.Ctx, page *PageDTO, key string, prop M
 	return true
 }
 
-func (i *Inertia) handleScrollProp(c fiber.Ctx, page *PageDTO, key string, prop ScrollProp, partial *partialConfig) bool {
+func (i *Inertia) handleScrollProp(c requestContext, page *PageDTO, key string, prop ScrollProp, partial *partialConfig) bool {
 	if page.ScrollProps == nil {
 		page.ScrollProps = make(map[string]ScrollPropConfig)
 	}
@@ -974,7 +1167,7 @@ func (i *Inertia) handleScrollProp(c fiber.Ctx, page *PageDTO, key string, prop
 }
 
 // renderJSON renders the page as JSON for Inertia requests.
-func (i *Inertia) renderJSON(c fiber.Ctx, page *PageDTO) error {
+func (i *Inertia) renderJSON(c requestContext, page *PageDTO) error {
 	js, err := json.Marshal(page)
 	if err != nil {
 		return fmt.Errorf("error marshaling page: %w", err)
@@ -991,7 +1184,7 @@ func (i *Inertia) renderJSON(c fiber.Ctx, page *PageDTO) error {
 }
 
 // renderHTML renders the page as HTML template.
-func (i *Inertia) renderHTML(c fiber.Ctx, page *PageDTO) error {
+func (i *Inertia) renderHTML(c requestContext, page *PageDTO) error {
 	rootTemplate, err := i.createRootTemplate()
 	if err != nil {
 		return err
@@ -1006,8 +1199,17 @@ func (i *Inertia) renderHTML(c fiber.Ctx, page *PageDTO) error {
 
 	viewData["page"] = page
 
-	if i.IsSSREnabled() {
-		ssr, err := i.processSSR(c, page)
+	useSSR := i.shouldRenderSSR(c, page.Component)
+
+	if useSSR && i.ssrConfig.Streaming {
+		streamed, err := i.renderHTMLStream(c, rootTemplate, viewData, page)
+		if streamed || err != nil {
+			return err
+		}
+	}
+
+	if useSSR {
+		ssr, err := i.ssrOrFallback(c, page)
 		if err != nil {
 			return err
 		}
@@ -1042,9 +1244,13 @@ func (i *Inertia) renderHTMLError(c fiber.Ctx, appErr *Error, details string) er
 	c.Status(appErrCur.Code)
 	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
 	var buf bytes.Buffer
+	rc := i.fiberCtx(c)
 	data := map[string]any{
-		"code":    appErrCur.Code,
-		"message": appErrCur.Message,
+		"code":        appErrCur.Code,
+		"message":     appErrCur.Message,
+		"locale":      i.locale(rc),
+		"heading":     i.errorPageMessage(rc, "title", appErrCur.Code),
+		"description": i.errorPageMessage(rc, "description", appErrCur.Code),
 	}
 	if details != "" {
 		data["details"] = details
@@ -1125,7 +1331,7 @@ func (i *Inertia) createRootErrorTemplate() (*template.Template, error) {
 	return i.parsedErrorTemplate, i.parsedErrorTemplateErr
 }
 
-func (i *Inertia) createViewData(c fiber.Ctx) (map[string]any, error) {
+func (i *Inertia) createViewData(c requestContext) (map[string]any, error) {
 	viewData := make(map[string]any)
 
 	// Add shared view data
@@ -1134,7 +1340,7 @@ func (i *Inertia) createViewData(c fiber.Ctx) (map[string]any, error) {
 	}
 
 	// Add context view data
-	contextViewData := c.Locals(ContextKeyViewData)
+	contextViewData := c.Local(ContextKeyViewData)
 	if contextViewData != nil {
 		contextViewData, ok := contextViewData.(map[string]any)
 		if !ok {
@@ -1177,19 +1383,19 @@ func (i *Inertia) hotServerURL() string {
 	return i.hotURL
 }
 
-func (i *Inertia) cacheLazy(c fiber.Ctx, key string, lazy LazyProp) (any, error) {
-	const cacheKey = "__inertia_lazy_cache"
-	cache, _ := c.Locals(cacheKey).(map[string]any)
+func (i *Inertia) cacheLazy(c requestContext, key string, lazy LazyProp) (any, error) {
+	const cacheKey = contextKey("__inertia_lazy_cache")
+	cache, _ := c.Local(cacheKey).(map[string]any)
 	if cache == nil {
 		cache = make(map[string]any)
-		c.Locals(cacheKey, cache)
+		c.SetLocal(cacheKey, cache)
 	}
 
 	if value, ok := cache[key]; ok {
 		return value, nil
 	}
 
-	result, err := lazy.Fn(c)
+	result, err := lazy.Fn(c.RequestContext())
 	if err != nil {
 		return nil, err
 	}
@@ -1199,19 +1405,19 @@ func (i *Inertia) cacheLazy(c fiber.Ctx, key string, lazy LazyProp) (any, error)
 	return result, nil
 }
 
-func (i *Inertia) resolvePropValue(c fiber.Ctx, key string, value any) (any, error) {
+func (i *Inertia) resolvePropValue(c requestContext, key string, value any) (any, error) {
 	switch val := value.(type) {
 	case LazyProp:
 		return i.cacheLazy(c, key, val)
 	case func(context.Context) (any, error):
-		return val(c)
+		return val(c.RequestContext())
 	default:
 		return value, nil
 	}
 }
 
-func (i *Inertia) applyPageMeta(c fiber.Ctx, page *PageDTO) {
-	meta := c.Locals(ContextKeyPageMeta)
+func (i *Inertia) applyPageMeta(c requestContext, page *PageDTO) {
+	meta := c.Local(ContextKeyPageMeta)
 	if meta == nil {
 		return
 	}
@@ -1245,7 +1451,7 @@ func (i *Inertia) applyPageMeta(c fiber.Ctx, page *PageDTO) {
 	}
 }
 
-func (i *Inertia) ensureErrorsProp(_ fiber.Ctx, page *PageDTO) {
+func (i *Inertia) ensureErrorsProp(_ requestContext, page *PageDTO) {
 	if page == nil {
 		return
 	}
@@ -1254,15 +1460,65 @@ func (i *Inertia) ensureErrorsProp(_ fiber.Ctx, page *PageDTO) {
 	}
 }
 
-func (i *Inertia) applyErrorBag(c fiber.Ctx, page *PageDTO) {
+// restoreValidationErrors returns errors flashed with every message in the form the errors prop is configured for.
+func (i *Inertia) restoreValidationErrors(errors map[string][]string) any {
+	if i.fullValidationErrors {
+		return ValidationErrors(errors)
+	}
+	return flattenValidationErrors(errors)
+}
+
+// restoreErrorBags returns flashed error bags in the form the errors prop is configured for.
+func (i *Inertia) restoreErrorBags(bags map[string]map[string][]string) any {
+	if i.fullValidationErrors {
+		res := make(map[string]ValidationErrors, len(bags))
+		for bag, errors := range bags {
+			res[bag] = errors
+		}
+		return res
+	}
+
+	res := make(map[string]map[string]string, len(bags))
+	for bag, errors := range bags {
+		res[bag] = flattenValidationErrors(errors)
+		if res[bag] == nil {
+			res[bag] = map[string]string{}
+		}
+	}
+	return res
+}
+
+func (i *Inertia) applyErrorBag(c requestContext, page *PageDTO) {
 	if page == nil {
 		return
 	}
+	if isErrorBags(page.Props[ContextPropsErrors]) {
+		// Already grouped by the handler.
+		return
+	}
 	bag := strings.TrimSpace(c.Get(HeaderErrorBag))
+	if restored := i.getContextKeyPageMeta(c).restoredErrorBags; len(restored) > 0 {
+		// Restored bags keep the names captured when they were flashed; the other errors join the current bag.
+		if bag == "" {
+			bag = DefaultErrorBag
+		}
+		bags := mergeErrorBag(restored, bag, normalizeValidationErrors(page.Props[ContextPropsErrors]))
+		page.Props[ContextPropsErrors] = i.restoreErrorBags(bags)
+		return
+	}
 	if bag == "" {
 		return
 	}
 
+	if i.fullValidationErrors {
+		errors := normalizeValidationErrors(page.Props[ContextPropsErrors])
+		if errors == nil {
+			errors = ValidationErrors{}
+		}
+		page.Props[ContextPropsErrors] = map[string]ValidationErrors{bag: errors}
+		return
+	}
+
 	flat := flattenValidationErrors(page.Props[ContextPropsErrors])
 	if flat == nil {
 		page.Props[ContextPropsErrors] = map[string]map[string]string{bag: {}}
@@ -1271,6 +1527,36 @@ func (i *Inertia) applyErrorBag(c fiber.Ctx, page *PageDTO) {
 	page.Props[ContextPropsErrors] = map[string]map[string]string{bag: flat}
 }
 
+// mergeErrorBag returns a copy of bags with errors added to bag, replacing the messages of the same fields.
+func mergeErrorBag(bags map[string]map[string][]string, bag string, errors ValidationErrors) map[string]map[string][]string {
+	res := make(map[string]map[string][]string, len(bags)+1)
+	for name, bagErrors := range bags {
+		res[name] = bagErrors
+	}
+	if len(errors) == 0 {
+		return res
+	}
+
+	merged := make(map[string][]string, len(res[bag])+len(errors))
+	for field, msgs := range res[bag] {
+		merged[field] = msgs
+	}
+	for field, msgs := range errors {
+		merged[field] = msgs
+	}
+	res[bag] = merged
+	return res
+}
+
+func isErrorBags(value any) bool {
+	switch value.(type) {
+	case map[string]map[string]string, map[string]ValidationErrors:
+		return true
+	default:
+		return false
+	}
+}
+
 func (i *Inertia) isExternalRedirect(target string) bool {
 	parsed, err := url.Parse(target)
 	if err != nil || !parsed.IsAbs() {
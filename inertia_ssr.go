@@ -5,7 +5,10 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v3"
@@ -21,28 +24,90 @@ const (
 	DefaultSSRRetryDelay   = 10 * time.Millisecond
 )
 
+// SSRCacheKeyFunc returns the SSR cache key for a page. An empty key skips the cache for that render.
+type SSRCacheKeyFunc func(page *PageDTO) string
+
 type SSRConfig struct {
-	URL             string
-	Timeout         time.Duration
-	Headers         map[string]string
-	CacheTTL        time.Duration
-	CacheMaxEntries int
-	SSRClient       SSRClient
-	MaxRetries      int
-	RetryDelay      time.Duration
-	RetryStatuses   []int
-	DisableRetries  bool
+	URL string
+	// URLs is a pool of SSR endpoints used instead of URL. Requests are balanced with Balance
+	// and retries go to a different endpoint.
+	URLs []string
+	// Balance is the pool strategy. Default: SSRBalanceRoundRobin.
+	Balance SSRBalanceStrategy
+	// EndpointCooldown is how long a pool endpoint is skipped after a failure. Default: 5s.
+	EndpointCooldown time.Duration
+	Timeout          time.Duration
+	Headers          map[string]string
+	CacheTTL         time.Duration
+	CacheMaxEntries  int
+	// Cache replaces the in-process LRU, e.g. with a FileSSRCache or a cache shared between replicas.
+	// Caching is enabled by CacheTTL either way.
+	Cache SSRCache
+	// StaleWhileRevalidate serves entries older than CacheTTL for up to this long while they are
+	// re-rendered in the background. Zero disables it.
+	StaleWhileRevalidate time.Duration
+	// CacheExcludeProps are top-level prop keys ignored by the cache key. The page data of a cached body is
+	// replaced with the current page, so excluded props reach the client, but the cached markup is reused:
+	// never exclude a prop rendered into the HTML, such as a CSRF token in a hidden input or meta tag.
+	CacheExcludeProps []string
+	// CacheKeyFuncs overrides the cache key per component.
+	CacheKeyFuncs map[string]SSRCacheKeyFunc
+	// Components limits SSR to components matching one of the patterns, e.g. "Public/*".
+	// A "*" matches any run of characters, including "/". Empty means every component.
+	Components []string
+	// ExcludeComponents are patterns of components always rendered client-side, e.g. "Admin/*".
+	ExcludeComponents []string
+	SSRClient         SSRClient
+	MaxRetries        int
+	RetryDelay        time.Duration
+	RetryStatuses     []int
+	DisableRetries    bool
+	// FallbackToCSR serves the client-side rendered shell when SSR fails instead of returning the error.
+	FallbackToCSR bool
+	// OnFallback is called for every render degraded to CSR (only with FallbackToCSR).
+	OnFallback func(ctx context.Context, component string, err error)
+	// CircuitBreaker stops calling a failing SSR server for a while. Disabled unless FailureThreshold is set.
+	CircuitBreaker SSRCircuitBreakerConfig
+	// Streaming sends the root template up to the first use of .processSSR while the SSR request is in flight.
+	// SSR errors then always fall back to CSR, since the status line has already been sent.
+	Streaming bool
+	// Process spawns and supervises the SSR server (see StartSSRProcess).
+	Process *SSRProcessConfig
+	// HealthURL is probed by CheckSSRHealth. Default: URL with its last path segment replaced by /health.
+	HealthURL string
+	// HealthCheckInterval is the probe period of StartSSRHealthChecks. Default: 10s.
+	HealthCheckInterval time.Duration
 }
 
+// defaultSSRClient sends requests over HTTP, or over the transport selected by a unix:// or stdio:// URL.
 type defaultSSRClient struct {
 	client *fiberclient.Client
+	unix   *unixSSRClient
+	stdio  *stdioSSRClient
+}
+
+func newDefaultSSRClient(logger Logger) *defaultSSRClient {
+	return &defaultSSRClient{
+		client: fiberclient.New(),
+		unix:   newUnixSSRClient(),
+		stdio:  newStdioSSRClient(logger),
+	}
 }
 
 func (c *defaultSSRClient) Reset() {
 	c.client.Reset()
+	c.unix.Reset()
+	c.stdio.Reset()
 }
 
 func (c *defaultSSRClient) Post(ctx context.Context, url string, body []byte, headers map[string]string) (int, []byte, error) {
+	switch ssrURLScheme(url) {
+	case ssrSchemeUnix:
+		return c.unix.Post(ctx, url, body, headers)
+	case ssrSchemeStdio:
+		return c.stdio.Post(ctx, url, body, headers)
+	}
+
 	reqCfg := fiberclient.Config{
 		Ctx:    ctx,
 		Body:   body,
@@ -57,6 +122,31 @@ func (c *defaultSSRClient) Post(ctx context.Context, url string, body []byte, he
 	return resp.StatusCode(), resp.Body(), nil
 }
 
+func (c *defaultSSRClient) Get(ctx context.Context, url string, headers map[string]string) (int, []byte, error) {
+	switch ssrURLScheme(url) {
+	case ssrSchemeUnix:
+		return c.unix.Get(ctx, url, headers)
+	case ssrSchemeStdio:
+		return c.stdio.Get(ctx, url, headers)
+	}
+
+	resp, err := c.client.Get(url, fiberclient.Config{
+		Ctx:    ctx,
+		Header: headers,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Close()
+
+	return resp.StatusCode(), resp.Body(), nil
+}
+
+// close stops the stdio processes.
+func (c *defaultSSRClient) close() {
+	c.stdio.close()
+}
+
 func (i *Inertia) IsSSREnabled() bool {
 	return i.ssrConfig.URL != "" && i.ssrClient != nil
 }
@@ -71,9 +161,12 @@ func (i *Inertia) EnableSSR(cfg SSRConfig) {
 	if i.ssrConfig.SSRClient != nil {
 		i.ssrClient = i.ssrConfig.SSRClient
 	} else if i.ssrClient == nil {
-		i.ssrClient = &defaultSSRClient{client: fiberclient.New()}
+		i.ssrClient = newDefaultSSRClient(i.logger)
 	}
 
+	i.ssrPool = newSSRPool(ssrConfigURLs(i.ssrConfig), i.ssrConfig.Balance, i.ssrConfig.EndpointCooldown)
+	i.ssrBreaker = newSSRBreaker(i.ssrConfig.CircuitBreaker, i.logSSRCircuitChange)
+	i.resetSSRHealth()
 	i.initSSRCache()
 }
 
@@ -90,12 +183,18 @@ func (i *Inertia) DisableSSR() {
 	i.ssrConfig = SSRConfig{}
 	if i.ssrClient != nil {
 		i.ssrClient.Reset()
+		if client, ok := i.ssrClient.(*defaultSSRClient); ok {
+			client.close()
+		}
 		i.ssrClient = nil
 	}
 	i.ssrCache = nil
+	i.ssrPool = nil
+	i.ssrBreaker = nil
+	i.resetSSRHealth()
 }
 
-func (i *Inertia) processSSR(c fiber.Ctx, page *PageDTO) (*SsrDTO, error) {
+func (i *Inertia) processSSR(c requestContext, page *PageDTO) (*SsrDTO, error) {
 	if !i.IsSSREnabled() {
 		return nil, nil //nolint:nilnil // is need
 	}
@@ -104,17 +203,57 @@ func (i *Inertia) processSSR(c fiber.Ctx, page *PageDTO) (*SsrDTO, error) {
 
 	js, err := json.Marshal(page)
 	if err != nil {
-		i.logger.ErrorContext(c, "SSR marshal failed", "error", err)
+		i.logger.ErrorContext(c.RequestContext(), "SSR marshal failed", "error", err)
 		return nil, fmt.Errorf("error marshaling page: %w", err)
 	}
 
-	cacheKey := ssrCacheKey(js)
+	var cacheKey string
 	if i.ssrCache != nil {
-		if cached, ok := i.ssrCache.Get(cacheKey); ok {
+		i.flushSSRCacheOnVersionChange(c, page.Version)
+		cacheKey, err = i.ssrPageCacheKey(page, js)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cacheKey != "" {
+		if cached, ok := i.ssrCacheLookup(c, cacheKey, js); ok {
 			return cached, nil
 		}
 	}
 
+	flightKey := cacheKey
+	if flightKey == "" {
+		flightKey = ssrComponentKeyPrefix(page.Component) + ssrCacheKey(js)
+	}
+
+	// The request is detached from the first caller, so its cancellation does not fail the other waiters.
+	flightCtx := context.WithoutCancel(c.UserContext())
+	flight := i.ssrFlights.DoChan(flightKey, func() (any, error) {
+		return i.requestSSR(flightCtx, js, cacheKey)
+	})
+
+	select {
+	case res := <-flight:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		ssr, _ := res.Val.(*SsrDTO)
+		if res.Shared && ssr != nil {
+			// Coalesced pages may differ in volatile props, so each waiter gets its own page data.
+			ssr = cloneSSR(ssr)
+			ssr.Body = injectSSRPage(ssr.Body, js)
+		}
+		return ssr, nil
+	case <-c.UserContext().Done():
+		return nil, fmt.Errorf("error waiting for ssr: %w", c.UserContext().Err())
+	}
+}
+
+// requestSSR posts the page to the SSR server with retries and caches the result.
+// It runs once per in-flight cache key, with a context detached from the waiting requests.
+func (i *Inertia) requestSSR(ctx context.Context, js []byte, cacheKey string) (*SsrDTO, error) {
+	var err error
+
 	reqHeader := map[string]string{
 		fiber.HeaderContentType: fiber.MIMEApplicationJSON,
 	}
@@ -124,22 +263,35 @@ func (i *Inertia) processSSR(c fiber.Ctx, page *PageDTO) (*SsrDTO, error) {
 		}
 	}
 
-	var reqCtx context.Context
-	reqCtx = c.Context()
-	var cancel context.CancelFunc
-	if i.ssrConfig.Timeout > 0 {
-		reqCtx, cancel = context.WithTimeout(reqCtx, i.ssrConfig.Timeout)
-	}
-	if cancel != nil {
-		defer cancel()
+	timeout := i.ssrConfig.Timeout
+	if timeout <= 0 {
+		timeout = DefaultSSRTimeout
 	}
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	var statusCode int
 	var body []byte
 	maxRetries := i.ssrConfig.MaxRetries
+	tried := make([]*ssrEndpoint, 0, maxRetries+1)
+	endpointURL := i.ssrConfig.URL
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		statusCode, body, err = i.ssrClient.Post(reqCtx, i.ssrConfig.URL, js, reqHeader)
+		if !i.ssrBreaker.allow() {
+			err = ErrSSRCircuitOpen
+			break
+		}
+		endpoint := i.ssrPool.pick(tried)
+		tried = append(tried, endpoint)
+		endpointURL = endpoint.url
+
+		endpoint.inFlight.Add(1)
+		statusCode, body, err = i.ssrClient.Post(reqCtx, endpoint.url, js, reqHeader)
+		endpoint.inFlight.Add(-1)
+
+		result := ssrResultOf(statusCode, err)
+		endpoint.setHealthy(result == ssrResultSuccess, i.ssrPool.cooldown)
+		i.ssrBreaker.done(result)
 		if err == nil && !shouldRetrySSRStatus(statusCode, i.ssrConfig.RetryStatuses) {
 			break
 		}
@@ -151,9 +303,9 @@ func (i *Inertia) processSSR(c fiber.Ctx, page *PageDTO) (*SsrDTO, error) {
 				i.ssrClient.Reset()
 			}
 			i.logger.WarnContext(
-				c, "SSR retrying request",
+				ctx, "SSR retrying request",
 				"attempt", attempt+1,
-				"url", i.ssrConfig.URL,
+				"url", endpointURL,
 				"status", statusCode,
 				"error", err,
 			)
@@ -163,50 +315,139 @@ func (i *Inertia) processSSR(c fiber.Ctx, page *PageDTO) (*SsrDTO, error) {
 		}
 	}
 
+	if errors.Is(err, ErrSSRCircuitOpen) {
+		return nil, err
+	}
 	if err != nil {
-		i.logger.ErrorContext(c, "SSR request failed", "error", err, "url", i.ssrConfig.URL)
+		i.logger.ErrorContext(ctx, "SSR request failed", "error", err, "url", endpointURL)
 		return nil, fmt.Errorf("error posting ssr: %w", err)
 	}
 
 	if statusCode >= 400 {
-		i.logger.ErrorContext(c, "SSR response error", "status", statusCode, "url", i.ssrConfig.URL)
+		i.logger.ErrorContext(ctx, "SSR response error", "status", statusCode, "url", endpointURL)
 		return nil, ErrBadSsrStatusCode
 	}
 
 	ssr := new(SsrDTO)
 	err = json.Unmarshal(body, ssr)
 	if err != nil {
-		i.logger.ErrorContext(c, "SSR unmarshal failed", "error", err)
+		i.logger.ErrorContext(ctx, "SSR unmarshal failed", "error", err)
 		return nil, fmt.Errorf("error unmarshalling ssr: %w", err)
 	}
+	ssr.RenderedAt = time.Now()
 
-	if i.ssrCache != nil {
-		i.ssrCache.Set(cacheKey, ssr)
+	if cacheKey != "" {
+		// Stale entries are kept for the revalidation window on top of the TTL.
+		ttl := i.ssrConfig.CacheTTL + i.ssrConfig.StaleWhileRevalidate
+		if err := i.ssrCache.Set(ctx, cacheKey, ssr, ttl); err != nil {
+			i.logger.WarnContext(ctx, "SSR cache set failed", "error", err)
+		}
 	}
 
 	return ssr, nil
 }
 
+// shouldRenderSSR reports whether the component is rendered on the SSR server for this request.
+// WithSSR takes precedence over the component patterns.
+func (i *Inertia) shouldRenderSSR(c requestContext, component string) bool {
+	if !i.IsSSREnabled() {
+		return false
+	}
+	if meta := i.getContextKeyPageMeta(c); meta.ssr != nil {
+		return *meta.ssr
+	}
+	if matchComponentPatterns(i.ssrConfig.ExcludeComponents, component) {
+		return false
+	}
+	return len(i.ssrConfig.Components) == 0 || matchComponentPatterns(i.ssrConfig.Components, component)
+}
+
+func matchComponentPatterns(patterns []string, component string) bool {
+	for _, pattern := range patterns {
+		if matchComponentPattern(pattern, component) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchComponentPattern matches a component name against a pattern where "*" matches any run of characters.
+func matchComponentPattern(pattern, component string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == component
+	}
+
+	last := len(parts) - 1
+	if !strings.HasPrefix(component, parts[0]) || !strings.HasSuffix(component[len(parts[0]):], parts[last]) {
+		return false
+	}
+	rest := component[len(parts[0]) : len(component)-len(parts[last])]
+	for _, part := range parts[1:last] {
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return true
+}
+
+// ssrOrFallback renders the page on the SSR server.
+// With FallbackToCSR a failure is reported and a nil result makes the template render the CSR shell.
+func (i *Inertia) ssrOrFallback(c requestContext, page *PageDTO) (*SsrDTO, error) {
+	ssr, err := i.processSSR(c, page)
+	if err == nil || !i.ssrConfig.FallbackToCSR {
+		return ssr, err
+	}
+
+	i.reportSSRFallback(c, page, err)
+	return nil, nil //nolint:nilnil // nil result renders the CSR shell
+}
+
+// reportSSRFallback logs a render degraded to CSR and calls OnFallback.
+func (i *Inertia) reportSSRFallback(c requestContext, page *PageDTO, err error) {
+	if errors.Is(err, ErrSSRCircuitOpen) {
+		i.logger.DebugContext(c.RequestContext(), "SSR circuit open, rendering client-side", "component", page.Component)
+	} else {
+		i.logger.WarnContext(c.RequestContext(), "SSR failed, falling back to client-side rendering",
+			"component", page.Component,
+			"error", err,
+		)
+	}
+	if i.ssrConfig.OnFallback != nil {
+		i.ssrConfig.OnFallback(c.UserContext(), page.Component, err)
+	}
+}
+
 func (i *Inertia) initSSRCache() {
 	if i.ssrConfig.CacheTTL <= 0 {
 		i.ssrCache = nil
 		return
 	}
+	if i.ssrConfig.Cache != nil {
+		i.ssrCache = i.ssrConfig.Cache
+		return
+	}
 	maxEntries := i.ssrConfig.CacheMaxEntries
 	if maxEntries <= 0 {
 		maxEntries = 256
 	}
-	i.ssrCache = newSSRCache(i.ssrConfig.CacheTTL, maxEntries)
+	i.ssrCache = newSSRCache(maxEntries)
 }
 
 func normalizeSSRConfig(cfg SSRConfig) SSRConfig {
+	if cfg.URL == "" && len(cfg.URLs) > 0 {
+		cfg.URL = cfg.URLs[0]
+	}
 	if cfg.URL == "" {
 		return SSRConfig{}
 	}
 	if cfg.DisableRetries {
 		cfg.MaxRetries = 0
 	} else if cfg.MaxRetries == 0 {
-		cfg.MaxRetries = DefaultSSRMaxRetries
+		// Every endpoint of a pool gets a chance before the render fails.
+		cfg.MaxRetries = max(DefaultSSRMaxRetries, len(cfg.URLs)-1)
 	}
 	if cfg.RetryDelay == 0 {
 		cfg.RetryDelay = DefaultSSRRetryDelay
@@ -214,6 +455,27 @@ func normalizeSSRConfig(cfg SSRConfig) SSRConfig {
 	return cfg
 }
 
+// ssrResultOf classifies an SSR response for the circuit breaker. Errors, timeouts included, and 5xx
+// statuses count as failures. The request context is detached from the client, so a client that
+// disconnects never cancels it.
+func ssrResultOf(statusCode int, err error) ssrResult {
+	switch {
+	case err != nil || statusCode >= 500:
+		return ssrResultFailure
+	default:
+		return ssrResultSuccess
+	}
+}
+
+func (i *Inertia) logSSRCircuitChange(from, to SSRCircuitState) {
+	ctx := context.Background()
+	if to == SSRCircuitOpen {
+		i.logger.WarnContext(ctx, "SSR circuit breaker opened", "from", string(from), "url", i.ssrConfig.URL)
+		return
+	}
+	i.logger.InfoContext(ctx, "SSR circuit breaker state changed", "from", string(from), "to", string(to), "url", i.ssrConfig.URL)
+}
+
 func shouldRetrySSRStatus(statusCode int, retryStatuses []int) bool {
 	if statusCode == 0 {
 		return true
@@ -236,3 +498,75 @@ func ssrCacheKey(payload []byte) string {
 	sum := sha256.Sum256(payload)
 	return hex.EncodeToString(sum[:])
 }
+
+// ssrPageCacheKey returns the cache key of a page: the component key func result,
+// or a hash of the page without the volatile props. An empty key means the page is not cached.
+func (i *Inertia) ssrPageCacheKey(page *PageDTO, js []byte) (string, error) {
+	if keyFunc, ok := i.ssrConfig.CacheKeyFuncs[page.Component]; ok && keyFunc != nil {
+		key := keyFunc(page)
+		if key == "" {
+			return "", nil
+		}
+		return ssrComponentKeyPrefix(page.Component) + ssrCacheKey([]byte(key)), nil
+	}
+
+	excluded := make([]string, 0, len(i.ssrConfig.CacheExcludeProps))
+	for _, key := range i.ssrConfig.CacheExcludeProps {
+		if _, ok := page.Props[key]; ok {
+			excluded = append(excluded, key)
+		}
+	}
+	if len(excluded) == 0 {
+		return ssrComponentKeyPrefix(page.Component) + ssrCacheKey(js), nil
+	}
+
+	stable := *page
+	stable.Props = make(map[string]any, len(page.Props))
+	for key, value := range page.Props {
+		stable.Props[key] = value
+	}
+	for _, key := range excluded {
+		delete(stable.Props, key)
+	}
+
+	payload, err := json.Marshal(&stable)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling page for ssr cache key: %w", err)
+	}
+	return ssrComponentKeyPrefix(page.Component) + ssrCacheKey(payload), nil
+}
+
+// injectSSRPage replaces the page data embedded in a cached SSR body with the current page,
+// either the data-page attribute of the root element or the <script data-page> JSON element.
+func injectSSRPage(body string, js []byte) string {
+	const attr = `data-page="`
+
+	offset := 0
+	for {
+		idx := strings.Index(body[offset:], attr)
+		if idx < 0 {
+			return body
+		}
+		start := offset + idx + len(attr)
+		end := strings.IndexByte(body[start:], '"')
+		if end < 0 {
+			return body
+		}
+		end += start
+
+		if strings.HasPrefix(body[start:end], "{") || strings.HasPrefix(body[start:end], "&#") ||
+			strings.HasPrefix(body[start:end], "&quot;") {
+			return body[:start] + html.EscapeString(string(js)) + body[end:]
+		}
+
+		tagStart := strings.LastIndexByte(body[:start], '<')
+		if tagStart >= 0 && strings.HasPrefix(body[tagStart:], "<script") {
+			contentStart := strings.IndexByte(body[end:], '>')
+			contentEnd := strings.Index(body[end:], "</script>")
+			if contentStart >= 0 && contentEnd > contentStart {
+				return body[:end+contentStart+1] + string(js) + body[end+contentEnd:]
+			}
+		}
+		offset = end
+	}
+}
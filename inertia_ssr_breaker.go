@@ -0,0 +1,181 @@
+package goinertia
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	DefaultSSRBreakerOpenTimeout    = 10 * time.Second
+	DefaultSSRBreakerHalfOpenProbes = 1
+)
+
+// SSRCircuitState is the state of the SSR circuit breaker.
+type SSRCircuitState string
+
+const (
+	// SSRCircuitClosed lets every request through to the SSR server.
+	SSRCircuitClosed SSRCircuitState = "closed"
+	// SSRCircuitOpen skips SSR until the open period has passed.
+	SSRCircuitOpen SSRCircuitState = "open"
+	// SSRCircuitHalfOpen lets a limited number of probe requests through.
+	SSRCircuitHalfOpen SSRCircuitState = "half-open"
+)
+
+// SSRCircuitBreakerConfig configures the circuit breaker around SSRClient.Post.
+type SSRCircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit. Zero disables the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before probing. Default: 10s.
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of concurrent probe requests allowed while half-open. Default: 1.
+	HalfOpenProbes int
+}
+
+// SSRStatus reports the state of server-side rendering.
+type SSRStatus struct {
+	Enabled             bool
+	Circuit             SSRCircuitState
+	ConsecutiveFailures int
+	// OpenedAt is when the circuit last opened; zero while closed.
+	OpenedAt time.Time
+	// ProcessRunning reports whether the process started by StartSSRProcess is running.
+	ProcessRunning bool
+	// ProcessRestarts counts restarts of the supervised process after crashes.
+	ProcessRestarts int
+	// Healthy is the value of SSRHealthy: the result of the last health probe or, before the first one,
+	// true unless the circuit is open.
+	Healthy bool
+	// LastHealthCheck is when the health endpoint was last probed; zero if never.
+	LastHealthCheck time.Time
+	// Endpoints reports every SSR endpoint; a single entry unless SSRConfig.URLs is set.
+	Endpoints []SSREndpointStatus
+}
+
+// SSRStatus returns the current SSR state, including the circuit breaker.
+func (i *Inertia) SSRStatus() SSRStatus {
+	status := SSRStatus{
+		Enabled: i.IsSSREnabled(),
+		Circuit: SSRCircuitClosed,
+	}
+	if i.ssrBreaker != nil {
+		status.Circuit, status.ConsecutiveFailures, status.OpenedAt = i.ssrBreaker.snapshot()
+	}
+	status.ProcessRunning, status.ProcessRestarts = i.ssrProcessStatus()
+	status.Healthy, status.LastHealthCheck = i.ssrHealthStatus()
+	status.Endpoints = i.ssrPool.status()
+	return status
+}
+
+// ssrResult is the outcome of a single SSR request as seen by the breaker.
+type ssrResult int
+
+const (
+	ssrResultSuccess ssrResult = iota
+	ssrResultFailure
+)
+
+type ssrBreaker struct {
+	mu             sync.Mutex
+	threshold      int
+	openTimeout    time.Duration
+	halfOpenProbes int
+	state          SSRCircuitState
+	failures       int
+	openedAt       time.Time
+	probes         int
+	now            func() time.Time
+	onStateChange  func(from, to SSRCircuitState)
+}
+
+func newSSRBreaker(cfg SSRCircuitBreakerConfig, onStateChange func(from, to SSRCircuitState)) *ssrBreaker {
+	if cfg.FailureThreshold <= 0 {
+		return nil
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultSSRBreakerOpenTimeout
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = DefaultSSRBreakerHalfOpenProbes
+	}
+
+	return &ssrBreaker{
+		threshold:      cfg.FailureThreshold,
+		openTimeout:    cfg.OpenTimeout,
+		halfOpenProbes: cfg.HalfOpenProbes,
+		state:          SSRCircuitClosed,
+		now:            time.Now,
+		onStateChange:  onStateChange,
+	}
+}
+
+// allow reports whether a request may be sent. Every allowed request must be followed by done.
+func (b *ssrBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case SSRCircuitOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.setState(SSRCircuitHalfOpen)
+		b.probes = 1
+		return true
+	case SSRCircuitHalfOpen:
+		if b.probes >= b.halfOpenProbes {
+			return false
+		}
+		b.probes++
+		return true
+	default:
+		return true
+	}
+}
+
+// done records the outcome of a request allowed by allow.
+func (b *ssrBreaker) done(result ssrResult) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == SSRCircuitHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+
+	switch result {
+	case ssrResultSuccess:
+		b.failures = 0
+		if b.state != SSRCircuitClosed {
+			b.openedAt = time.Time{}
+			b.setState(SSRCircuitClosed)
+		}
+	case ssrResultFailure:
+		b.failures++
+		if b.state == SSRCircuitHalfOpen || (b.state == SSRCircuitClosed && b.failures >= b.threshold) {
+			b.openedAt = b.now()
+			b.setState(SSRCircuitOpen)
+		}
+	}
+}
+
+func (b *ssrBreaker) snapshot() (SSRCircuitState, int, time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state, b.failures, b.openedAt
+}
+
+func (b *ssrBreaker) setState(state SSRCircuitState) {
+	from := b.state
+	b.state = state
+	if from != state && b.onStateChange != nil {
+		b.onStateChange(from, state)
+	}
+}
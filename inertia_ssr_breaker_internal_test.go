@@ -0,0 +1,71 @@
+package goinertia
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSRBreaker_Disabled(t *testing.T) {
+	t.Parallel()
+
+	var b *ssrBreaker
+	require.Nil(t, newSSRBreaker(SSRCircuitBreakerConfig{}, nil))
+	assert.True(t, b.allow())
+	assert.NotPanics(t, func() { b.done(ssrResultFailure) })
+}
+
+func TestSSRBreaker_Transitions(t *testing.T) {
+	t.Parallel()
+
+	var transitions []SSRCircuitState
+	b := newSSRBreaker(SSRCircuitBreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+	}, func(_, to SSRCircuitState) {
+		transitions = append(transitions, to)
+	})
+	now := time.Unix(1000, 0)
+	b.now = func() time.Time { return now }
+
+	require.True(t, b.allow())
+	b.done(ssrResultFailure)
+	require.True(t, b.allow())
+	b.done(ssrResultFailure)
+
+	state, failures, openedAt := b.snapshot()
+	assert.Equal(t, SSRCircuitOpen, state)
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, now, openedAt)
+	assert.False(t, b.allow())
+
+	now = now.Add(time.Minute)
+	require.True(t, b.allow(), "first probe after the open period")
+	assert.False(t, b.allow(), "only one probe while half-open")
+	b.done(ssrResultFailure)
+	state, _, _ = b.snapshot()
+	assert.Equal(t, SSRCircuitOpen, state)
+
+	now = now.Add(time.Minute)
+	require.True(t, b.allow())
+	b.done(ssrResultSuccess)
+	state, failures, _ = b.snapshot()
+	assert.Equal(t, SSRCircuitClosed, state)
+	assert.Zero(t, failures)
+
+	assert.Equal(t, []SSRCircuitState{
+		SSRCircuitOpen, SSRCircuitHalfOpen, SSRCircuitOpen, SSRCircuitHalfOpen, SSRCircuitClosed,
+	}, transitions)
+}
+
+func TestSSRResultOf(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ssrResultSuccess, ssrResultOf(200, nil))
+	assert.Equal(t, ssrResultSuccess, ssrResultOf(404, nil))
+	assert.Equal(t, ssrResultFailure, ssrResultOf(503, nil))
+	assert.Equal(t, ssrResultFailure, ssrResultOf(0, context.DeadlineExceeded))
+}
@@ -2,75 +2,206 @@ package goinertia
 
 import (
 	"container/list"
+	"context"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
 
+// SSRCacheStats counts SSR cache activity.
+type SSRCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	// StaleHits counts hits served stale while the entry was refreshed in the background (included in Hits).
+	StaleHits uint64
+	// Evictions counts live entries dropped to make room for new ones.
+	Evictions uint64
+	// Expirations counts entries dropped because their TTL had passed.
+	Expirations uint64
+}
+
+// PurgeSSRCache removes every cached SSR result.
+func (i *Inertia) PurgeSSRCache(ctx context.Context) error {
+	if i.ssrCache == nil {
+		return nil
+	}
+	if err := i.ssrCache.Purge(ctx); err != nil {
+		return fmt.Errorf("error purging ssr cache: %w", err)
+	}
+	return nil
+}
+
+// InvalidateSSRComponent removes the cached SSR results of a component.
+// Backends that do not implement SSRCachePrefixDeleter are purged entirely.
+func (i *Inertia) InvalidateSSRComponent(ctx context.Context, component string) error {
+	if i.ssrCache == nil {
+		return nil
+	}
+
+	deleter, ok := i.ssrCache.(SSRCachePrefixDeleter)
+	if !ok {
+		return i.PurgeSSRCache(ctx)
+	}
+	if err := deleter.DeletePrefix(ctx, ssrComponentKeyPrefix(component)); err != nil {
+		return fmt.Errorf("error invalidating ssr cache for %q: %w", component, err)
+	}
+	return nil
+}
+
+// SSRCacheStats returns the cache counters. Hits and misses are counted per render,
+// evictions and expirations are reported by backends implementing SSRCacheStatsProvider.
+func (i *Inertia) SSRCacheStats() SSRCacheStats {
+	stats := SSRCacheStats{
+		Hits:      i.ssrCacheHits.Load(),
+		Misses:    i.ssrCacheMisses.Load(),
+		StaleHits: i.ssrCacheStaleHits.Load(),
+	}
+	if provider, ok := i.ssrCache.(SSRCacheStatsProvider); ok {
+		backend := provider.Stats()
+		stats.Evictions = backend.Evictions
+		stats.Expirations = backend.Expirations
+	}
+	return stats
+}
+
+// ssrCacheLookup returns the cached result for key with the current page data injected.
+// A stale entry within the StaleWhileRevalidate window is returned as is and refreshed in the background.
+func (i *Inertia) ssrCacheLookup(c requestContext, key string, js []byte) (*SsrDTO, bool) {
+	cached, ok, err := i.ssrCache.Get(c.UserContext(), key)
+	if err != nil {
+		i.logger.WarnContext(c.RequestContext(), "SSR cache get failed", "error", err)
+	}
+	if !ok || cached == nil {
+		i.ssrCacheMisses.Add(1)
+		return nil, false
+	}
+
+	if i.ssrCacheStale(cached) {
+		if i.ssrConfig.StaleWhileRevalidate <= 0 {
+			i.ssrCacheMisses.Add(1)
+			return nil, false
+		}
+		i.ssrCacheStaleHits.Add(1)
+		refreshCtx := context.WithoutCancel(c.UserContext())
+		i.ssrFlights.DoChan(key, func() (any, error) {
+			return i.requestSSR(refreshCtx, js, key)
+		})
+	}
+
+	i.ssrCacheHits.Add(1)
+	cached.Body = injectSSRPage(cached.Body, js)
+	return cached, true
+}
+
+// ssrCacheStale reports whether an entry is older than CacheTTL. Entries without RenderedAt are fresh.
+func (i *Inertia) ssrCacheStale(entry *SsrDTO) bool {
+	return !entry.RenderedAt.IsZero() && time.Since(entry.RenderedAt) > i.ssrConfig.CacheTTL
+}
+
+// flushSSRCacheOnVersionChange purges the cache when the asset version differs from the last rendered one,
+// since cached markup references the old assets.
+func (i *Inertia) flushSSRCacheOnVersionChange(c requestContext, version string) {
+	prev := i.ssrCacheVersion.Load()
+	if prev != nil && *prev == version {
+		return
+	}
+	if !i.ssrCacheVersion.CompareAndSwap(prev, &version) || prev == nil {
+		return
+	}
+
+	i.logger.InfoContext(c.RequestContext(), "Asset version changed, purging SSR cache", "from", *prev, "to", version)
+	if err := i.PurgeSSRCache(c.UserContext()); err != nil {
+		i.logger.WarnContext(c.RequestContext(), "SSR cache purge failed", "error", err)
+	}
+}
+
+func ssrComponentKeyPrefix(component string) string {
+	return component + ":"
+}
+
 type ssrCacheEntry struct {
 	value     SsrDTO
 	expiresAt time.Time
 	element   *list.Element
 }
 
+// ssrCache is the default in-process LRU SSRCache.
 type ssrCache struct {
 	mu         sync.Mutex
-	ttl        time.Duration
 	maxEntries int
 	items      map[string]*ssrCacheEntry
 	order      *list.List
+	stats      SSRCacheStats
+	now        func() time.Time
 }
 
-func newSSRCache(ttl time.Duration, maxEntries int) *ssrCache {
-	if ttl <= 0 || maxEntries <= 0 {
+var (
+	_ SSRCache              = (*ssrCache)(nil)
+	_ SSRCachePrefixDeleter = (*ssrCache)(nil)
+	_ SSRCacheStatsProvider = (*ssrCache)(nil)
+)
+
+func newSSRCache(maxEntries int) *ssrCache {
+	if maxEntries <= 0 {
 		return nil
 	}
 
 	return &ssrCache{
-		ttl:        ttl,
 		maxEntries: maxEntries,
 		items:      make(map[string]*ssrCacheEntry, maxEntries),
 		order:      list.New(),
+		now:        time.Now,
 	}
 }
 
-func (c *ssrCache) Get(key string) (*SsrDTO, bool) {
+func (c *ssrCache) Get(_ context.Context, key string) (*SsrDTO, bool, error) {
 	if c == nil {
-		return nil, false
+		return nil, false, nil
 	}
 
-	now := time.Now()
+	now := c.now()
 	c.mu.Lock()
 	entry, ok := c.items[key]
 	if !ok {
+		c.stats.Misses++
 		c.mu.Unlock()
-		return nil, false
+		return nil, false, nil
 	}
 
 	if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
 		c.removeEntryLocked(key, entry)
+		c.stats.Misses++
+		c.stats.Expirations++
 		c.mu.Unlock()
-		return nil, false
+		return nil, false, nil
 	}
 
+	c.stats.Hits++
 	c.order.MoveToFront(entry.element)
 	value := cloneSSR(&entry.value)
 	c.mu.Unlock()
-	return value, true
+	return value, true, nil
 }
 
-func (c *ssrCache) Set(key string, value *SsrDTO) {
+func (c *ssrCache) Set(_ context.Context, key string, value *SsrDTO, ttl time.Duration) error {
 	if c == nil || value == nil {
-		return
+		return nil
 	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
 	if entry, ok := c.items[key]; ok {
 		entry.value = *cloneSSR(value)
-		entry.expiresAt = time.Now().Add(c.ttl)
+		entry.expiresAt = expiresAt
 		c.order.MoveToFront(entry.element)
-		return
+		return nil
 	}
 
 	if len(c.items) >= c.maxEntries {
@@ -80,13 +211,41 @@ func (c *ssrCache) Set(key string, value *SsrDTO) {
 	elem := c.order.PushFront(key)
 	c.items[key] = &ssrCacheEntry{
 		value:     *cloneSSR(value),
-		expiresAt: time.Now().Add(c.ttl),
+		expiresAt: expiresAt,
 		element:   elem,
 	}
+	return nil
+}
+
+func (c *ssrCache) Delete(_ context.Context, key string) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.items[key]; ok {
+		c.removeEntryLocked(key, entry)
+	}
+	return nil
+}
+
+func (c *ssrCache) Purge(context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]*ssrCacheEntry, c.maxEntries)
+	c.order.Init()
+	return nil
 }
 
 func (c *ssrCache) evictOneLocked() {
-	tm := time.Now()
+	tm := c.now()
 	for elem := c.order.Back(); elem != nil; elem = elem.Prev() {
 		key, ok := elem.Value.(string)
 		if !ok {
@@ -100,6 +259,7 @@ func (c *ssrCache) evictOneLocked() {
 		}
 		if !entry.expiresAt.IsZero() && tm.After(entry.expiresAt) {
 			c.removeEntryLocked(key, entry)
+			c.stats.Expirations++
 			return
 		}
 	}
@@ -119,6 +279,33 @@ func (c *ssrCache) evictOneLocked() {
 		return
 	}
 	c.removeEntryLocked(key, entry)
+	c.stats.Evictions++
+}
+
+func (c *ssrCache) DeletePrefix(_ context.Context, prefix string) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.removeEntryLocked(key, entry)
+		}
+	}
+	return nil
+}
+
+func (c *ssrCache) Stats() SSRCacheStats {
+	if c == nil {
+		return SSRCacheStats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
 }
 
 func (c *ssrCache) removeEntryLocked(key string, entry *ssrCacheEntry) {
@@ -134,7 +321,8 @@ func cloneSSR(src *SsrDTO) *SsrDTO {
 	}
 
 	dst := &SsrDTO{
-		Body: src.Body,
+		Body:       src.Body,
+		RenderedAt: src.RenderedAt,
 	}
 
 	if len(src.Head) > 0 {
@@ -0,0 +1,201 @@
+package goinertia
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+const fileSSRCacheExt = ".ssr.json"
+
+// ErrSSRCacheDirEmpty is returned by NewFileSSRCache without a directory.
+var ErrSSRCacheDirEmpty = errors.New("inertia: SSR cache directory is empty")
+
+// FileSSRCache is an SSRCache that keeps one JSON file per entry in a directory.
+// It survives restarts and can be shared by processes on the same host.
+type FileSSRCache struct {
+	dir string
+	now func() time.Time
+
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	expirations atomic.Uint64
+}
+
+var (
+	_ SSRCache              = (*FileSSRCache)(nil)
+	_ SSRCachePrefixDeleter = (*FileSSRCache)(nil)
+	_ SSRCacheStatsProvider = (*FileSSRCache)(nil)
+)
+
+type fileSSRCacheEntry struct {
+	Key        string    `json:"key"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	RenderedAt time.Time `json:"renderedAt"`
+	Head       []string  `json:"head"`
+	Body       string    `json:"body"`
+}
+
+// NewFileSSRCache creates the directory if needed and returns a cache stored in it.
+//
+// Example:
+//
+//	cache, err := goinertia.NewFileSSRCache("storage/ssr-cache")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	goinertia.WithSSRConfig(goinertia.SSRConfig{URL: ssrURL, CacheTTL: time.Hour, Cache: cache})
+func NewFileSSRCache(dir string) (*FileSSRCache, error) {
+	if dir == "" {
+		return nil, ErrSSRCacheDirEmpty
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("error creating ssr cache directory: %w", err)
+	}
+	return &FileSSRCache{dir: dir, now: time.Now}, nil
+}
+
+func (c *FileSSRCache) Get(_ context.Context, key string) (*SsrDTO, bool, error) {
+	path := c.path(key)
+	entry, err := readFileSSRCacheEntry(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		c.misses.Add(1)
+		return nil, false, nil
+	}
+	if err != nil {
+		c.misses.Add(1)
+		return nil, false, err
+	}
+	if c.expired(entry) {
+		_ = os.Remove(path)
+		c.misses.Add(1)
+		c.expirations.Add(1)
+		return nil, false, nil
+	}
+
+	c.hits.Add(1)
+	return &SsrDTO{Head: entry.Head, Body: entry.Body, RenderedAt: entry.RenderedAt}, true, nil
+}
+
+// Set writes the entry to a temporary file and renames it, so readers never see a partial entry.
+func (c *FileSSRCache) Set(_ context.Context, key string, value *SsrDTO, ttl time.Duration) error {
+	if value == nil {
+		return nil
+	}
+
+	entry := fileSSRCacheEntry{Key: key, RenderedAt: value.RenderedAt, Head: value.Head, Body: value.Body}
+	if ttl > 0 {
+		entry.ExpiresAt = c.now().Add(ttl)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding ssr cache entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating ssr cache entry: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("error writing ssr cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing ssr cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("error writing ssr cache entry: %w", err)
+	}
+	return nil
+}
+
+func (c *FileSSRCache) Delete(_ context.Context, key string) error {
+	if err := os.Remove(c.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error deleting ssr cache entry: %w", err)
+	}
+	return nil
+}
+
+// Purge removes every entry. Other files in the directory are left alone.
+func (c *FileSSRCache) Purge(context.Context) error {
+	return c.removeWhere(func(string) bool { return true })
+}
+
+// DeletePrefix removes the entries whose key starts with prefix. It reads every entry, so it is meant for
+// occasional invalidation rather than the request path.
+func (c *FileSSRCache) DeletePrefix(_ context.Context, prefix string) error {
+	return c.removeWhere(func(path string) bool {
+		entry, err := readFileSSRCacheEntry(path)
+		return err == nil && strings.HasPrefix(entry.Key, prefix)
+	})
+}
+
+// Stats returns the cache counters. Entries are never evicted, so Evictions is always zero.
+func (c *FileSSRCache) Stats() SSRCacheStats {
+	return SSRCacheStats{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Expirations: c.expirations.Load(),
+	}
+}
+
+func (c *FileSSRCache) removeWhere(match func(path string) bool) error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("error reading ssr cache directory: %w", err)
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileSSRCacheExt) {
+			continue
+		}
+		path := filepath.Join(c.dir, entry.Name())
+		if !match(path) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error removing ssr cache entries: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func (c *FileSSRCache) expired(entry fileSSRCacheEntry) bool {
+	return !entry.ExpiresAt.IsZero() && c.now().After(entry.ExpiresAt)
+}
+
+func readFileSSRCacheEntry(path string) (fileSSRCacheEntry, error) {
+	var entry fileSSRCacheEntry
+
+	data, err := os.ReadFile(path) // #nosec G304 - the file name is a hash inside the cache directory
+	if err != nil {
+		return entry, fmt.Errorf("error reading ssr cache entry: %w", err)
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		_ = os.Remove(path)
+		return entry, fmt.Errorf("error decoding ssr cache entry: %w", err)
+	}
+	return entry, nil
+}
+
+// path hashes the key, so any key maps to a safe file name.
+func (c *FileSSRCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+fileSSRCacheExt)
+}
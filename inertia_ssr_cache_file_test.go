@@ -0,0 +1,208 @@
+package goinertia_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+	"github.com/assurrussa/goinertia/inertiat/fibert"
+)
+
+func TestFileSSRCache(t *testing.T) {
+	t.Parallel()
+
+	_, err := goinertia.NewFileSSRCache("")
+	require.ErrorIs(t, err, goinertia.ErrSSRCacheDirEmpty)
+
+	dir := filepath.Join(t.TempDir(), "ssr")
+	cache, err := goinertia.NewFileSSRCache(dir)
+	require.NoError(t, err)
+	ctx := t.Context()
+
+	_, ok, err := cache.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	value := &goinertia.SsrDTO{Head: []string{"<title>Home</title>"}, Body: "<div>Home</div>"}
+	require.NoError(t, cache.Set(ctx, "../home", value, time.Minute))
+	require.NoError(t, cache.Set(ctx, "users", &goinertia.SsrDTO{Body: "<div>Users</div>"}, 0))
+	require.NoError(t, cache.Set(ctx, "expired", &goinertia.SsrDTO{Body: "old"}, time.Nanosecond))
+
+	// Entries survive a new instance over the same directory.
+	reopened, err := goinertia.NewFileSSRCache(dir)
+	require.NoError(t, err)
+	got, ok, err := reopened.Get(ctx, "../home")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, value, got)
+
+	time.Sleep(time.Millisecond)
+	_, ok, err = cache.Get(ctx, "expired")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, cache.Delete(ctx, "../home"))
+	require.NoError(t, cache.Delete(ctx, "../home"))
+	_, ok, _ = cache.Get(ctx, "../home")
+	assert.False(t, ok)
+
+	require.NoError(t, cache.Set(ctx, "Users:1", &goinertia.SsrDTO{Body: "<div>Users</div>"}, 0))
+	require.NoError(t, cache.DeletePrefix(ctx, "Users:"))
+	_, ok, _ = cache.Get(ctx, "Users:1")
+	assert.False(t, ok)
+	_, ok, _ = cache.Get(ctx, "users")
+	assert.True(t, ok)
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(4), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Expirations)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keep.txt"), []byte("x"), 0o600))
+	require.NoError(t, cache.Purge(ctx))
+	_, ok, _ = cache.Get(ctx, "users")
+	assert.False(t, ok)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "keep.txt", files[0].Name())
+}
+
+func TestInertia_SSR_CacheInvalidation(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	client := &mockSSRClient{
+		onPost: func(_ context.Context) (int, []byte, error) {
+			atomic.AddInt32(&attempts, 1)
+			return http.StatusOK, []byte(`{"body":"<h1>Page</h1>","head":[]}`), nil
+		},
+	}
+
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:             "http://ssr.local",
+			SSRClient:       client,
+			CacheTTL:        time.Minute,
+			CacheMaxEntries: 10,
+		}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	render := func(component string) {
+		t.Helper()
+		require.NoError(t, ta.Inrt.Render(fibert.Default(), component, nil))
+	}
+	ctx := t.Context()
+
+	render("Home")
+	render("Users")
+	render("Home")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.Equal(t, goinertia.SSRCacheStats{Hits: 1, Misses: 2}, ta.Inrt.SSRCacheStats())
+
+	require.NoError(t, ta.Inrt.InvalidateSSRComponent(ctx, "Home"))
+	render("Users")
+	render("Home")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	require.NoError(t, ta.Inrt.PurgeSSRCache(ctx))
+	render("Users")
+	assert.Equal(t, int32(4), atomic.LoadInt32(&attempts))
+	assert.Equal(t, goinertia.SSRCacheStats{Hits: 2, Misses: 4}, ta.Inrt.SSRCacheStats())
+}
+
+func TestInertia_SSR_CacheFlushOnVersionChange(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cache, err := goinertia.NewFileSSRCache(dir)
+	require.NoError(t, err)
+
+	assets := fstest.MapFS{"app.js": {Data: []byte("v1")}}
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithDevMode(),
+		goinertia.WithAssetVersionFromFS(assets, "app.js"),
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL: "http://ssr.local",
+			SSRClient: &mockSSRClient{onPost: func(context.Context) (int, []byte, error) {
+				return http.StatusOK, []byte(`{"body":"<h1>Page</h1>","head":[]}`), nil
+			}},
+			CacheTTL: time.Minute,
+			Cache:    cache,
+		}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	require.NoError(t, ta.Inrt.Render(fibert.Default(), "Home", nil))
+	require.NoError(t, ta.Inrt.Render(fibert.Default(), "Users", nil))
+	assertDirLen(t, dir, 2)
+
+	// A rebuild changes the modtime the dev mode asset version is checked against.
+	assets["app.js"] = &fstest.MapFile{Data: []byte("v2"), ModTime: time.Now()}
+	require.NoError(t, ta.Inrt.Render(fibert.Default(), "Home", nil))
+	assertDirLen(t, dir, 1)
+}
+
+func assertDirLen(t *testing.T, dir string, n int) {
+	t.Helper()
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, n)
+}
+
+func TestInertia_SSR_CustomCache(t *testing.T) {
+	t.Parallel()
+
+	cache, err := goinertia.NewFileSSRCache(t.TempDir())
+	require.NoError(t, err)
+
+	var attempts int32
+	client := &mockSSRClient{
+		onPost: func(_ context.Context) (int, []byte, error) {
+			atomic.AddInt32(&attempts, 1)
+			return http.StatusOK, []byte(`{"body":"<h1>Cached</h1>","head":[]}`), nil
+		},
+	}
+
+	tmpDir := createSSRTemplates(t)
+	newApp := func() inertiat.TestApp {
+		ta := inertiat.NewTestAppWithoutMiddleware(t,
+			goinertia.WithSSRConfig(goinertia.SSRConfig{
+				URL:       "http://ssr.local",
+				SSRClient: client,
+				CacheTTL:  time.Minute,
+				Cache:     cache,
+			}),
+			goinertia.WithFS(os.DirFS(tmpDir)),
+			goinertia.WithRootTemplate("ssr.gohtml"),
+		)
+		require.NoError(t, ta.Inrt.ParseTemplates())
+		return ta
+	}
+
+	require.NoError(t, newApp().Inrt.Render(fibert.Default(), "Home", nil))
+
+	// A second instance (another replica) reuses the shared entry.
+	c := fibert.Default()
+	require.NoError(t, newApp().Inrt.Render(c, "Home", nil))
+	assert.Contains(t, string(c.Response().Body()), "<h1>Cached</h1>")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
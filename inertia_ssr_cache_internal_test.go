@@ -1,6 +1,7 @@
 package goinertia
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -11,20 +12,23 @@ import (
 func TestSSRCache_Nil(t *testing.T) {
 	t.Parallel()
 
-	cache := newSSRCache(0, 0)
+	cache := newSSRCache(0)
 	require.Nil(t, cache)
 
-	cache = newSSRCache(-1, -1)
+	cache = newSSRCache(-1)
 	require.Nil(t, cache)
 
 	var c *ssrCache
 
-	val, ok := c.Get("key-1")
+	val, ok, err := c.Get(t.Context(), "key-1")
+	require.NoError(t, err)
 	require.False(t, ok)
 	require.Nil(t, val)
 
 	assert.NotPanics(t, func() {
-		c.Set("key-1", &SsrDTO{Body: "body-1"})
+		require.NoError(t, c.Set(t.Context(), "key-1", &SsrDTO{Body: "body-1"}, time.Minute))
+		require.NoError(t, c.Delete(t.Context(), "key-1"))
+		require.NoError(t, c.Purge(t.Context()))
 	})
 }
 
@@ -38,26 +42,27 @@ func TestSSRCache_Clone(t *testing.T) {
 func TestSSRCache_GetSet(t *testing.T) {
 	t.Parallel()
 
-	cache := newSSRCache(100*time.Millisecond, 2)
+	ttl := 100 * time.Millisecond
+	cache := newSSRCache(2)
 	require.NotNil(t, cache)
 
 	value := &SsrDTO{
 		Head: []string{"head-1"},
 		Body: "body-1",
 	}
-	cache.Set("key-1", value)
+	require.NoError(t, cache.Set(t.Context(), "key-1", value, ttl))
 
-	got, ok := cache.Get("key-1")
+	got, ok, _ := cache.Get(t.Context(), "key-1")
 	require.True(t, ok)
 	require.NotNil(t, got)
-	got2, ok2 := cache.Get("key-2")
+	got2, ok2, _ := cache.Get(t.Context(), "key-2")
 	require.False(t, ok2)
 	require.Nil(t, got2)
 	assert.Equal(t, "body-1", got.Body)
 	assert.Equal(t, []string{"head-1"}, got.Head)
 
 	got.Head[0] = "mutated"
-	gotAgain, ok := cache.Get("key-1")
+	gotAgain, ok, _ := cache.Get(t.Context(), "key-1")
 	require.True(t, ok)
 	assert.Equal(t, []string{"head-1"}, gotAgain.Head)
 }
@@ -65,30 +70,32 @@ func TestSSRCache_GetSet(t *testing.T) {
 func TestSSRCache_Expire(t *testing.T) {
 	t.Parallel()
 
-	cache := newSSRCache(20*time.Millisecond, 2)
+	ttl := 20 * time.Millisecond
+	cache := newSSRCache(2)
 	require.NotNil(t, cache)
 
-	cache.Set("key-1", &SsrDTO{Body: "body-1"})
+	require.NoError(t, cache.Set(t.Context(), "key-1", &SsrDTO{Body: "body-1"}, ttl))
 	time.Sleep(30 * time.Millisecond)
 
-	_, ok := cache.Get("key-1")
+	_, ok, _ := cache.Get(t.Context(), "key-1")
 	assert.False(t, ok)
 
-	cache.Set("key-1", &SsrDTO{Body: "body-1"})
-	cache.Set("key-2", &SsrDTO{Body: "body-2"})
+	require.NoError(t, cache.Set(t.Context(), "key-1", &SsrDTO{Body: "body-1"}, ttl))
+	require.NoError(t, cache.Set(t.Context(), "key-2", &SsrDTO{Body: "body-2"}, ttl))
 	time.Sleep(30 * time.Millisecond)
-	cache.Set("key-1", &SsrDTO{Body: "body-1"})
+	require.NoError(t, cache.Set(t.Context(), "key-1", &SsrDTO{Body: "body-1"}, ttl))
 }
 
 func TestSSRCache_MaxEntries(t *testing.T) {
 	t.Parallel()
 
-	cache := newSSRCache(100*time.Millisecond, 2)
+	ttl := 100 * time.Millisecond
+	cache := newSSRCache(2)
 	require.NotNil(t, cache)
 
-	cache.Set("key-1", &SsrDTO{Body: "body-1"})
-	cache.Set("key-2", &SsrDTO{Body: "body-2"})
-	cache.Set("key-3", &SsrDTO{Body: "body-3"})
+	require.NoError(t, cache.Set(t.Context(), "key-1", &SsrDTO{Body: "body-1"}, ttl))
+	require.NoError(t, cache.Set(t.Context(), "key-2", &SsrDTO{Body: "body-2"}, ttl))
+	require.NoError(t, cache.Set(t.Context(), "key-3", &SsrDTO{Body: "body-3"}, ttl))
 
 	cache.mu.Lock()
 	defer cache.mu.Unlock()
@@ -98,43 +105,167 @@ func TestSSRCache_MaxEntries(t *testing.T) {
 func TestSSRCache_LRU(t *testing.T) {
 	t.Parallel()
 
-	cache := newSSRCache(100*time.Millisecond, 2)
+	ttl := 100 * time.Millisecond
+	cache := newSSRCache(2)
 	require.NotNil(t, cache)
 
-	cache.Set("key-1", &SsrDTO{Body: "body-1"})
-	cache.Set("key-2", &SsrDTO{Body: "body-2"})
+	require.NoError(t, cache.Set(t.Context(), "key-1", &SsrDTO{Body: "body-1"}, ttl))
+	require.NoError(t, cache.Set(t.Context(), "key-2", &SsrDTO{Body: "body-2"}, ttl))
 
-	_, ok := cache.Get("key-1") // key-2 becomes LRU
+	_, ok, _ := cache.Get(t.Context(), "key-1") // key-2 becomes LRU
 	require.True(t, ok)
 
-	cache.Set("key-3", &SsrDTO{Body: "body-3"})
+	require.NoError(t, cache.Set(t.Context(), "key-3", &SsrDTO{Body: "body-3"}, ttl))
 
-	_, ok = cache.Get("key-2")
+	_, ok, _ = cache.Get(t.Context(), "key-2")
 	assert.False(t, ok)
-	_, ok = cache.Get("key-1")
+	_, ok, _ = cache.Get(t.Context(), "key-1")
 	assert.True(t, ok)
-	_, ok = cache.Get("key-3")
+	_, ok, _ = cache.Get(t.Context(), "key-3")
 	assert.True(t, ok)
 }
 
 func TestSSRCache_EvictExpiredBeforeLRU(t *testing.T) {
 	t.Parallel()
 
-	cache := newSSRCache(40*time.Millisecond, 2)
+	now := time.Unix(0, 0)
+	ttl := 40 * time.Millisecond
+	cache := newSSRCache(2)
 	require.NotNil(t, cache)
+	cache.now = func() time.Time { return now }
 
-	cache.Set("key-1", &SsrDTO{Body: "body-1"})
-	time.Sleep(25 * time.Millisecond)
+	require.NoError(t, cache.Set(t.Context(), "key-1", &SsrDTO{Body: "body-1"}, ttl))
+	now = now.Add(25 * time.Millisecond)
 
-	cache.Set("key-2", &SsrDTO{Body: "body-2"})
-	time.Sleep(25 * time.Millisecond) // key-1 expired, key-2 still valid
+	require.NoError(t, cache.Set(t.Context(), "key-2", &SsrDTO{Body: "body-2"}, ttl))
+	now = now.Add(25 * time.Millisecond) // key-1 expired, key-2 still valid
 
-	cache.Set("key-3", &SsrDTO{Body: "body-3"})
+	require.NoError(t, cache.Set(t.Context(), "key-3", &SsrDTO{Body: "body-3"}, ttl))
 
-	_, ok := cache.Get("key-1")
+	_, ok, _ := cache.Get(t.Context(), "key-1")
 	assert.False(t, ok)
-	_, ok = cache.Get("key-2")
+	_, ok, _ = cache.Get(t.Context(), "key-2")
 	assert.True(t, ok)
-	_, ok = cache.Get("key-3")
+	_, ok, _ = cache.Get(t.Context(), "key-3")
 	assert.True(t, ok)
 }
+
+func TestSSRCache_DeletePurge(t *testing.T) {
+	t.Parallel()
+
+	cache := newSSRCache(10)
+	require.NotNil(t, cache)
+
+	require.NoError(t, cache.Set(t.Context(), "key-1", &SsrDTO{Body: "body-1"}, 0))
+	require.NoError(t, cache.Set(t.Context(), "key-2", &SsrDTO{Body: "body-2"}, time.Minute))
+
+	require.NoError(t, cache.Delete(t.Context(), "key-1"))
+	_, ok, _ := cache.Get(t.Context(), "key-1")
+	assert.False(t, ok)
+	_, ok, _ = cache.Get(t.Context(), "key-2")
+	assert.True(t, ok)
+
+	require.NoError(t, cache.Purge(t.Context()))
+	_, ok, _ = cache.Get(t.Context(), "key-2")
+	assert.False(t, ok)
+	assert.Zero(t, cache.order.Len())
+}
+
+func TestSSRCache_Stats(t *testing.T) {
+	t.Parallel()
+
+	cache := newSSRCache(2)
+	require.NotNil(t, cache)
+	ctx := t.Context()
+
+	require.NoError(t, cache.Set(ctx, "Home:1", &SsrDTO{Body: "home"}, time.Nanosecond))
+	require.NoError(t, cache.Set(ctx, "Users:1", &SsrDTO{Body: "users-1"}, time.Minute))
+	time.Sleep(time.Millisecond)
+
+	_, ok, _ := cache.Get(ctx, "Home:1") // expired
+	assert.False(t, ok)
+	_, ok, _ = cache.Get(ctx, "Users:1")
+	assert.True(t, ok)
+	_, ok, _ = cache.Get(ctx, "Users:2")
+	assert.False(t, ok)
+
+	require.NoError(t, cache.Set(ctx, "Users:2", &SsrDTO{Body: "users-2"}, time.Minute))
+	require.NoError(t, cache.Set(ctx, "Posts:1", &SsrDTO{Body: "posts"}, time.Minute)) // evicts Users:1
+
+	assert.Equal(t, SSRCacheStats{Hits: 1, Misses: 2, Evictions: 1, Expirations: 1}, cache.Stats())
+
+	require.NoError(t, cache.DeletePrefix(ctx, "Users:"))
+	_, ok, _ = cache.Get(ctx, "Users:2")
+	assert.False(t, ok)
+	_, ok, _ = cache.Get(ctx, "Posts:1")
+	assert.True(t, ok)
+}
+
+func TestSSRCache_InjectPage(t *testing.T) {
+	t.Parallel()
+
+	js := []byte(`{"component":"Home","props":{"csrf_token":"new"}}`)
+	escaped := `{&#34;component&#34;:&#34;Home&#34;,&#34;props&#34;:{&#34;csrf_token&#34;:&#34;new&#34;}}`
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "attribute",
+			body: `<div id="app" data-page="{&quot;props&quot;:{&quot;csrf_token&quot;:&quot;old&quot;}}"><h1>Home</h1></div>`,
+			want: `<div id="app" data-page="` + escaped + `"><h1>Home</h1></div>`,
+		},
+		{
+			name: "script element",
+			body: `<script data-page="app" type="application/json">{"props":{"csrf_token":"old"}}</script><div id="app"></div>`,
+			want: `<script data-page="app" type="application/json">` + string(js) + `</script><div id="app"></div>`,
+		},
+		{
+			name: "no page data",
+			body: `<div id="app"><h1>Home</h1></div>`,
+			want: `<div id="app"><h1>Home</h1></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, injectSSRPage(tt.body, js))
+		})
+	}
+}
+
+func TestSSRCache_PageKey(t *testing.T) {
+	t.Parallel()
+
+	i := New("http://localhost.loc:3000", WithSSRConfig(SSRConfig{
+		URL:               "http://ssr.local",
+		CacheTTL:          time.Minute,
+		CacheExcludeProps: []string{"now"},
+		CacheKeyFuncs: map[string]SSRCacheKeyFunc{
+			"Static":   func(*PageDTO) string { return "v1" },
+			"Uncached": func(*PageDTO) string { return "" },
+		},
+	}))
+
+	key := func(page *PageDTO) string {
+		t.Helper()
+		js, err := json.Marshal(page)
+		require.NoError(t, err)
+		got, err := i.ssrPageCacheKey(page, js)
+		require.NoError(t, err)
+		return got
+	}
+
+	home := key(&PageDTO{Component: "Home", Props: map[string]any{"title": "a", "now": 1, "csrf_token": "x"}})
+	assert.Equal(t, home, key(&PageDTO{Component: "Home", Props: map[string]any{"title": "a", "now": 2, "csrf_token": "x"}}))
+	// The CSRF token may be rendered into the markup, so it is part of the key unless excluded explicitly.
+	assert.NotEqual(t, home, key(&PageDTO{Component: "Home", Props: map[string]any{"title": "a", "now": 1, "csrf_token": "y"}}))
+	assert.NotEqual(t, home, key(&PageDTO{Component: "Home", Props: map[string]any{"title": "b", "now": 1}}))
+
+	static := key(&PageDTO{Component: "Static", Props: map[string]any{"title": "a"}})
+	assert.Equal(t, static, key(&PageDTO{Component: "Static", Props: map[string]any{"title": "b"}}))
+	assert.Empty(t, key(&PageDTO{Component: "Uncached"}))
+}
@@ -0,0 +1,269 @@
+package goinertia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// DefaultSSRHealthCheckInterval is used by StartSSRHealthChecks when SSRConfig.HealthCheckInterval is not set.
+const DefaultSSRHealthCheckInterval = 10 * time.Second
+
+// ssrHealthState is the result of the last health probe.
+type ssrHealthState int
+
+const (
+	ssrHealthUnknown ssrHealthState = iota
+	ssrHealthUp
+	ssrHealthDown
+)
+
+func (s ssrHealthState) String() string {
+	switch s {
+	case ssrHealthUp:
+		return "healthy"
+	case ssrHealthDown:
+		return "unhealthy"
+	default:
+		return "unknown"
+	}
+}
+
+type ssrHealth struct {
+	mu        sync.RWMutex
+	state     ssrHealthState
+	checkedAt time.Time
+}
+
+// SSRHealthy reports whether the SSR server is usable.
+// With health checks it is the result of the last probe, otherwise SSR must be enabled with a closed circuit.
+func (i *Inertia) SSRHealthy() bool {
+	healthy, _ := i.ssrHealthStatus()
+	return healthy
+}
+
+// CheckSSRHealth probes the SSR health endpoint once and records the result.
+// With SSRConfig.URLs every endpoint is probed; SSR is healthy while at least one endpoint is.
+func (i *Inertia) CheckSSRHealth(ctx context.Context) error {
+	if !i.IsSSREnabled() {
+		return ErrSSRDisabled
+	}
+
+	err := i.probeSSRHealth(ctx)
+	i.setSSRHealth(ctx, err)
+	return err
+}
+
+// StartSSRHealthChecks probes the SSR server every SSRConfig.HealthCheckInterval until ctx is done.
+// The first probe runs before it returns, so SSRHealthy is accurate right away.
+func (i *Inertia) StartSSRHealthChecks(ctx context.Context) {
+	interval := i.ssrConfig.HealthCheckInterval
+	if interval <= 0 {
+		interval = DefaultSSRHealthCheckInterval
+	}
+
+	_ = i.CheckSSRHealth(ctx)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = i.CheckSSRHealth(ctx)
+			}
+		}
+	}()
+}
+
+// SSRReadinessHandler responds 200 when SSR is healthy or disabled and 503 otherwise.
+// Mount it on a readiness endpoint:
+//
+//	app.Get("/readyz/ssr", inertiaManager.SSRReadinessHandler())
+func (i *Inertia) SSRReadinessHandler() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		code, body := i.ssrReadiness()
+		return c.Status(code).JSON(body)
+	}
+}
+
+// SSRReadinessHandler is the net/http variant of Inertia.SSRReadinessHandler.
+func (a *HTTPAdapter) SSRReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		code, body := a.inertia.ssrReadiness()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_, _ = fmt.Fprintf(w, `{"ssr":%q}`, body["ssr"])
+	})
+}
+
+func (i *Inertia) ssrReadiness() (int, map[string]string) {
+	switch {
+	case !i.IsSSREnabled():
+		return http.StatusOK, map[string]string{"ssr": "disabled"}
+	case i.SSRHealthy():
+		return http.StatusOK, map[string]string{"ssr": ssrHealthUp.String()}
+	default:
+		return http.StatusServiceUnavailable, map[string]string{"ssr": ssrHealthDown.String()}
+	}
+}
+
+func (i *Inertia) probeSSRHealth(ctx context.Context) error {
+	pool := i.ssrPool
+	if pool == nil {
+		return ErrSSRDisabled
+	}
+
+	var errs []error
+	for _, endpoint := range pool.endpoints {
+		err := i.probeSSREndpoint(ctx, endpoint.url, len(pool.endpoints) == 1)
+		endpoint.setHealthy(err == nil, pool.cooldown)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	switch {
+	case len(errs) < len(pool.endpoints):
+		return nil
+	case len(errs) == 1:
+		return errs[0]
+	default:
+		return errors.Join(errs...)
+	}
+}
+
+func (i *Inertia) probeSSREndpoint(ctx context.Context, renderURL string, single bool) error {
+	healthURL, err := i.ssrHealthURL(renderURL, single)
+	if err != nil {
+		return err
+	}
+
+	timeout := i.ssrConfig.Timeout
+	if timeout <= 0 {
+		timeout = DefaultSSRTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client, ok := i.ssrClient.(SSRHealthClient)
+	if !ok {
+		client = i.ssrHealthClient()
+	}
+
+	statusCode, _, err := client.Get(ctx, healthURL, i.ssrConfig.Headers)
+	if err != nil {
+		return fmt.Errorf("error probing ssr health of %s: %w", healthURL, err)
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("%w: health status %d from %s", ErrBadSsrStatusCode, statusCode, healthURL)
+	}
+	return nil
+}
+
+// ssrHealthClient returns a client for SSRClient implementations without health support.
+func (i *Inertia) ssrHealthClient() SSRHealthClient {
+	i.ssrHealthClientOnce.Do(func() {
+		i.ssrHealthFallback = newDefaultSSRClient(i.logger)
+	})
+	return i.ssrHealthFallback
+}
+
+// ssrHealthURL returns the render URL with its last path segment replaced by /health.
+// SSRConfig.HealthURL takes precedence unless there is a pool of endpoints.
+func (i *Inertia) ssrHealthURL(renderURL string, single bool) (string, error) {
+	if i.ssrConfig.HealthURL != "" && single {
+		return i.ssrConfig.HealthURL, nil
+	}
+
+	parsed, err := url.Parse(renderURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid SSR URL: %w", err)
+	}
+	switch parsed.Scheme {
+	case ssrSchemeStdio:
+		// A stdio process is healthy when it runs; there is no separate endpoint.
+		return renderURL, nil
+	case ssrSchemeUnix:
+		// The socket is the path of a unix:// URL, the HTTP path is in the query.
+		query := parsed.Query()
+		query.Set("path", ssrHealthPath(unixSSRRequestPath(parsed)))
+		parsed.RawQuery = query.Encode()
+		return parsed.String(), nil
+	}
+	parsed.Path = ssrHealthPath(parsed.Path)
+	parsed.RawQuery = ""
+	return parsed.String(), nil
+}
+
+// ssrHealthPath replaces the last segment of the render path with /health.
+func ssrHealthPath(renderPath string) string {
+	path := strings.TrimSuffix(renderPath, "/")
+	if idx := strings.LastIndex(path, "/"); idx >= 0 {
+		path = path[:idx]
+	}
+	return path + "/health"
+}
+
+func (i *Inertia) setSSRHealth(ctx context.Context, err error) {
+	state := ssrHealthUp
+	if err != nil {
+		state = ssrHealthDown
+	}
+
+	i.ssrHealth.mu.Lock()
+	prev := i.ssrHealth.state
+	i.ssrHealth.state = state
+	i.ssrHealth.checkedAt = time.Now()
+	i.ssrHealth.mu.Unlock()
+
+	if prev == state {
+		return
+	}
+	if state == ssrHealthDown {
+		i.logger.WarnContext(ctx, "SSR server became unhealthy", "from", prev.String(), "error", err)
+		return
+	}
+	i.logger.InfoContext(ctx, "SSR server is healthy", "from", prev.String())
+}
+
+func (i *Inertia) resetSSRHealth() {
+	i.ssrHealth.mu.Lock()
+	i.ssrHealth.state = ssrHealthUnknown
+	i.ssrHealth.checkedAt = time.Time{}
+	i.ssrHealth.mu.Unlock()
+}
+
+// ssrHealthStatus returns whether SSR is healthy and when it was last probed. It backs both SSRHealthy
+// and SSRStatus: before the first probe SSR counts as healthy unless the circuit is open.
+func (i *Inertia) ssrHealthStatus() (bool, time.Time) {
+	i.ssrHealth.mu.RLock()
+	state, checkedAt := i.ssrHealth.state, i.ssrHealth.checkedAt
+	i.ssrHealth.mu.RUnlock()
+
+	if !i.IsSSREnabled() {
+		return false, checkedAt
+	}
+	switch state {
+	case ssrHealthUp:
+		return true, checkedAt
+	case ssrHealthDown:
+		return false, checkedAt
+	default:
+		if i.ssrBreaker == nil {
+			return true, checkedAt
+		}
+		circuit, _, _ := i.ssrBreaker.snapshot()
+		return circuit != SSRCircuitOpen, checkedAt
+	}
+}
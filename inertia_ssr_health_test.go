@@ -0,0 +1,121 @@
+package goinertia_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+)
+
+func TestInertia_SSRHealth(t *testing.T) {
+	t.Parallel()
+
+	var healthy atomic.Bool
+	healthy.Store(true)
+	var probes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			http.NotFound(w, r)
+			return
+		}
+		probes.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	logs := &syncBuffer{}
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithLogger(goinertia.NewLoggerAdapter(slog.New(slog.NewTextHandler(logs, nil)))),
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:                 server.URL + "/render",
+			HealthCheckInterval: 10 * time.Millisecond,
+		}),
+	)
+
+	// Before the first probe SSR is assumed healthy, by both accessors.
+	assert.True(t, ta.Inrt.SSRHealthy())
+	assert.True(t, ta.Inrt.SSRStatus().Healthy)
+	assert.True(t, ta.Inrt.SSRStatus().LastHealthCheck.IsZero())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ta.Inrt.StartSSRHealthChecks(ctx)
+
+	assert.True(t, ta.Inrt.SSRHealthy())
+	status := ta.Inrt.SSRStatus()
+	assert.True(t, status.Healthy)
+	assert.False(t, status.LastHealthCheck.IsZero())
+	assertReadiness(t, ta, http.StatusOK, "healthy")
+
+	healthy.Store(false)
+	require.Eventually(t, func() bool { return !ta.Inrt.SSRHealthy() }, 5*time.Second, 10*time.Millisecond)
+	assertReadiness(t, ta, http.StatusServiceUnavailable, "unhealthy")
+
+	healthy.Store(true)
+	require.Eventually(t, ta.Inrt.SSRHealthy, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.Contains(t, logs.String(), "SSR server became unhealthy")
+	assert.Contains(t, logs.String(), "SSR server is healthy")
+	assert.GreaterOrEqual(t, probes.Load(), int32(3))
+}
+
+func TestInertia_SSRHealth_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ta := inertiat.NewTestAppWithoutMiddleware(t)
+
+	assert.False(t, ta.Inrt.SSRHealthy())
+	require.ErrorIs(t, ta.Inrt.CheckSSRHealth(context.Background()), goinertia.ErrSSRDisabled)
+	assertReadiness(t, ta, http.StatusOK, "disabled")
+}
+
+func TestInertia_SSRHealth_CustomURL(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	inrt := inertiat.NewForTest("http://localhost.loc:3000",
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:       server.URL + "/render",
+			HealthURL: server.URL + "/status",
+			SSRClient: &mockSSRClient{},
+		}),
+	)
+	require.NoError(t, inrt.CheckSSRHealth(context.Background()))
+	assert.True(t, inrt.SSRStatus().Healthy)
+
+	inrt.EnableSSR(goinertia.SSRConfig{URL: server.URL + "/render", SSRClient: &mockSSRClient{}})
+	require.ErrorIs(t, inrt.CheckSSRHealth(context.Background()), goinertia.ErrBadSsrStatusCode)
+	assert.False(t, inrt.SSRHealthy())
+}
+
+func assertReadiness(t *testing.T, ta inertiat.TestApp, code int, state string) {
+	t.Helper()
+
+	resp, body := ta.DoGet(ta.Inrt.SSRReadinessHandler(), map[string]string{"path": "/readyz"})
+
+	var payload map[string]string
+	require.NoError(t, json.Unmarshal([]byte(body), &payload))
+	assert.Equal(t, code, resp.StatusCode)
+	assert.Equal(t, state, payload["ssr"])
+}
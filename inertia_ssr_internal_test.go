@@ -0,0 +1,80 @@
+package goinertia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSR_MatchComponentPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern   string
+		component string
+		want      bool
+	}{
+		{pattern: "Home", component: "Home", want: true},
+		{pattern: "Home", component: "HomePage", want: false},
+		{pattern: "*", component: "Admin/Users", want: true},
+		{pattern: "Public/*", component: "Public/Home", want: true},
+		{pattern: "Public/*", component: "Public/Posts/Show", want: true},
+		{pattern: "Public/*", component: "Public", want: false},
+		{pattern: "Public/*", component: "Admin/Public/Home", want: false},
+		{pattern: "*/Index", component: "Users/Index", want: true},
+		{pattern: "*/Index", component: "Users/IndexPage", want: false},
+		{pattern: "Admin/*/Edit", component: "Admin/Users/Edit", want: true},
+		{pattern: "Admin/*/Edit", component: "Admin/Edit", want: false},
+		{pattern: "a*b*c", component: "abc", want: true},
+		{pattern: "ab*b", component: "ab", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.component, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, matchComponentPattern(tt.pattern, tt.component))
+		})
+	}
+}
+
+func TestSSR_TransportURLs(t *testing.T) {
+	t.Parallel()
+
+	socket, path, err := parseUnixSSRURL("unix:///run/ssr.sock")
+	require.NoError(t, err)
+	assert.Equal(t, "/run/ssr.sock", socket)
+	assert.Equal(t, "/render", path)
+
+	_, path, err = parseUnixSSRURL("unix:///run/ssr.sock?path=ssr/render")
+	require.NoError(t, err)
+	assert.Equal(t, "/ssr/render", path)
+
+	command, args, err := parseStdioSSRURL("stdio://node?arg=public/ssr/ssr.js&arg=--quiet")
+	require.NoError(t, err)
+	assert.Equal(t, "node", command)
+	assert.Equal(t, []string{"public/ssr/ssr.js", "--quiet"}, args)
+
+	command, _, err = parseStdioSSRURL("stdio:///usr/bin/node")
+	require.NoError(t, err)
+	assert.Equal(t, "/usr/bin/node", command)
+
+	_, _, err = parseStdioSSRURL("stdio://")
+	require.Error(t, err)
+
+	network, addr, err := ssrURLAddr("unix:///run/ssr.sock")
+	require.NoError(t, err)
+	assert.Equal(t, "unix", network)
+	assert.Equal(t, "/run/ssr.sock", addr)
+
+	_, _, err = ssrURLAddr("stdio://node")
+	require.Error(t, err)
+
+	i := &Inertia{}
+	healthURL, err := i.ssrHealthURL("unix:///run/ssr.sock?path=/ssr/render", true)
+	require.NoError(t, err)
+	assert.Equal(t, "unix:///run/ssr.sock?path=%2Fssr%2Fhealth", healthURL)
+
+	healthURL, err = i.ssrHealthURL("stdio://node?arg=ssr.js", true)
+	require.NoError(t, err)
+	assert.Equal(t, "stdio://node?arg=ssr.js", healthURL)
+}
@@ -0,0 +1,143 @@
+package goinertia
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultSSREndpointCooldown is how long a failed endpoint of SSRConfig.URLs is skipped.
+const DefaultSSREndpointCooldown = 5 * time.Second
+
+// SSRBalanceStrategy selects the endpoint of SSRConfig.URLs for each SSR request.
+type SSRBalanceStrategy string
+
+const (
+	// SSRBalanceRoundRobin cycles through the endpoints.
+	SSRBalanceRoundRobin SSRBalanceStrategy = "round-robin"
+	// SSRBalanceLeastInFlight picks the endpoint with the fewest requests in flight.
+	SSRBalanceLeastInFlight SSRBalanceStrategy = "least-in-flight"
+)
+
+// SSREndpointStatus reports the state of one SSR endpoint.
+type SSREndpointStatus struct {
+	URL      string
+	Healthy  bool
+	InFlight int64
+}
+
+type ssrEndpoint struct {
+	url      string
+	inFlight atomic.Int64
+
+	mu        sync.Mutex
+	downUntil time.Time
+	down      bool
+}
+
+func (e *ssrEndpoint) healthy(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return !e.down || now.After(e.downUntil)
+}
+
+// setHealthy records a request or probe outcome. A failure takes the endpoint out of rotation for cooldown.
+func (e *ssrEndpoint) setHealthy(ok bool, cooldown time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.down = !ok
+	e.downUntil = time.Time{}
+	if !ok {
+		e.downUntil = time.Now().Add(cooldown)
+	}
+}
+
+// ssrPool balances SSR requests over the configured endpoints.
+type ssrPool struct {
+	endpoints []*ssrEndpoint
+	strategy  SSRBalanceStrategy
+	cooldown  time.Duration
+	next      atomic.Uint64
+}
+
+func newSSRPool(urls []string, strategy SSRBalanceStrategy, cooldown time.Duration) *ssrPool {
+	if cooldown <= 0 {
+		cooldown = DefaultSSREndpointCooldown
+	}
+
+	pool := &ssrPool{strategy: strategy, cooldown: cooldown}
+	for _, u := range urls {
+		pool.endpoints = append(pool.endpoints, &ssrEndpoint{url: u})
+	}
+	return pool
+}
+
+// pick returns the endpoint for the next attempt. Endpoints already tried by the request are skipped
+// when possible, and healthy endpoints are preferred over ones in cooldown.
+func (p *ssrPool) pick(tried []*ssrEndpoint) *ssrEndpoint {
+	if len(p.endpoints) == 1 {
+		return p.endpoints[0]
+	}
+
+	now := time.Now()
+	candidates := make([]*ssrEndpoint, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		if ep.healthy(now) && !slices.Contains(tried, ep) {
+			candidates = append(candidates, ep)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, ep := range p.endpoints {
+			if !slices.Contains(tried, ep) {
+				candidates = append(candidates, ep)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = p.endpoints
+	}
+
+	start := int(p.next.Add(1)-1) % len(candidates)
+	if p.strategy != SSRBalanceLeastInFlight {
+		return candidates[start]
+	}
+
+	best := candidates[start]
+	for n := 1; n < len(candidates); n++ {
+		ep := candidates[(start+n)%len(candidates)]
+		if ep.inFlight.Load() < best.inFlight.Load() {
+			best = ep
+		}
+	}
+	return best
+}
+
+func (p *ssrPool) status() []SSREndpointStatus {
+	if p == nil {
+		return nil
+	}
+
+	now := time.Now()
+	statuses := make([]SSREndpointStatus, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		statuses = append(statuses, SSREndpointStatus{
+			URL:      ep.url,
+			Healthy:  ep.healthy(now),
+			InFlight: ep.inFlight.Load(),
+		})
+	}
+	return statuses
+}
+
+// ssrConfigURLs returns the endpoints of the config: URLs, or URL alone.
+func ssrConfigURLs(cfg SSRConfig) []string {
+	if len(cfg.URLs) > 0 {
+		return cfg.URLs
+	}
+	if cfg.URL != "" {
+		return []string{cfg.URL}
+	}
+	return nil
+}
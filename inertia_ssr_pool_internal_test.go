@@ -0,0 +1,62 @@
+package goinertia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSRPool_RoundRobin(t *testing.T) {
+	t.Parallel()
+
+	pool := newSSRPool([]string{"a", "b", "c"}, SSRBalanceRoundRobin, time.Minute)
+
+	var picked []string
+	for range 4 {
+		picked = append(picked, pool.pick(nil).url)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, picked)
+
+	// Endpoints already tried by the request are skipped.
+	tried := []*ssrEndpoint{pool.endpoints[1]}
+	assert.NotEqual(t, "b", pool.pick(tried).url)
+	assert.Equal(t, "c", pool.pick(pool.endpoints[:2]).url)
+	assert.NotEmpty(t, pool.pick(pool.endpoints).url)
+}
+
+func TestSSRPool_LeastInFlight(t *testing.T) {
+	t.Parallel()
+
+	pool := newSSRPool([]string{"a", "b", "c"}, SSRBalanceLeastInFlight, time.Minute)
+	pool.endpoints[0].inFlight.Store(2)
+	pool.endpoints[1].inFlight.Store(1)
+	pool.endpoints[2].inFlight.Store(3)
+
+	for range 3 {
+		assert.Equal(t, "b", pool.pick(nil).url)
+	}
+}
+
+func TestSSRPool_Cooldown(t *testing.T) {
+	t.Parallel()
+
+	pool := newSSRPool([]string{"a", "b"}, SSRBalanceRoundRobin, 20*time.Millisecond)
+	pool.endpoints[0].setHealthy(false, pool.cooldown)
+
+	for range 3 {
+		assert.Equal(t, "b", pool.pick(nil).url)
+	}
+	assert.Equal(t, []SSREndpointStatus{{URL: "a"}, {URL: "b", Healthy: true}}, pool.status())
+
+	// With every endpoint down the request still goes somewhere.
+	pool.endpoints[1].setHealthy(false, pool.cooldown)
+	assert.NotEmpty(t, pool.pick(nil).url)
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, []SSREndpointStatus{{URL: "a", Healthy: true}, {URL: "b", Healthy: true}}, pool.status())
+
+	pool.endpoints[0].setHealthy(false, pool.cooldown)
+	pool.endpoints[0].setHealthy(true, pool.cooldown)
+	assert.True(t, pool.endpoints[0].healthy(time.Now()))
+}
@@ -0,0 +1,330 @@
+package goinertia
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	DefaultSSRProcessStartTimeout   = 10 * time.Second
+	DefaultSSRProcessStopTimeout    = 5 * time.Second
+	DefaultSSRProcessRestartBackoff = 500 * time.Millisecond
+	DefaultSSRProcessMaxBackoff     = 30 * time.Second
+	ssrProcessReadyPollInterval     = 50 * time.Millisecond
+)
+
+var (
+	// ErrSSRProcessNotConfigured is returned when SSRConfig.Process is not set.
+	ErrSSRProcessNotConfigured = errors.New("inertia: SSR process is not configured")
+	// ErrSSRProcessRunning is returned by StartSSRProcess when the process is already supervised.
+	ErrSSRProcessRunning = errors.New("inertia: SSR process is already running")
+)
+
+// SSRProcessConfig describes a Node SSR server spawned and supervised by goinertia.
+type SSRProcessConfig struct {
+	// Command is the executable, e.g. "node".
+	Command string
+	// Args are the command arguments, e.g. []string{"public/ssr/ssr.js"}.
+	Args []string
+	// Dir is the working directory. Default: the current directory.
+	Dir string
+	// Env is appended to the current environment.
+	Env []string
+	// Addr is the host:port to wait for. Default: the host of SSRConfig.URL, or its socket for a unix:// URL.
+	Addr string
+	// StartTimeout bounds how long StartSSRProcess waits for Addr to accept connections. Default: 10s.
+	StartTimeout time.Duration
+	// StopTimeout is the grace period between SIGTERM and SIGKILL. Default: 5s.
+	StopTimeout time.Duration
+	// RestartBackoff is the first delay before restarting a crashed process; it doubles up to MaxBackoff.
+	// Default: 500ms.
+	RestartBackoff time.Duration
+	// MaxBackoff caps the restart delay. A process that ran longer than MaxBackoff resets the delay. Default: 30s.
+	MaxBackoff time.Duration
+}
+
+// StartSSRProcess spawns the SSR server from SSRConfig.Process and waits until it accepts connections.
+// The process is restarted with backoff when it exits, until StopSSRProcess is called.
+//
+// Example:
+//
+//	if err := inertiaManager.StartSSRProcess(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+//	inertiaManager.StopSSRProcessOnShutdown(app)
+//	log.Fatal(app.Listen(":3000"))
+func (i *Inertia) StartSSRProcess(ctx context.Context) error {
+	cfg := i.ssrConfig.Process
+	if cfg == nil || cfg.Command == "" {
+		return ErrSSRProcessNotConfigured
+	}
+
+	i.ssrProcessMu.Lock()
+	if i.ssrProcess != nil {
+		i.ssrProcessMu.Unlock()
+		return ErrSSRProcessRunning
+	}
+	proc, err := newSSRProcess(*cfg, i.ssrConfig.URL, i.logger)
+	if err != nil {
+		i.ssrProcessMu.Unlock()
+		return err
+	}
+	i.ssrProcess = proc
+	i.ssrProcessMu.Unlock()
+
+	proc.start()
+	if err := proc.waitReady(ctx); err != nil {
+		_ = i.StopSSRProcess(context.Background())
+		return err
+	}
+
+	return nil
+}
+
+// StopSSRProcess stops the supervised SSR server and waits for it to exit.
+func (i *Inertia) StopSSRProcess(ctx context.Context) error {
+	i.ssrProcessMu.Lock()
+	proc := i.ssrProcess
+	i.ssrProcess = nil
+	i.ssrProcessMu.Unlock()
+
+	if proc == nil {
+		return nil
+	}
+	return proc.stop(ctx)
+}
+
+// StopSSRProcessOnShutdown stops the supervised SSR server when the Fiber app shuts down.
+func (i *Inertia) StopSSRProcessOnShutdown(app *fiber.App) {
+	app.Hooks().OnPostShutdown(func(error) error {
+		return i.StopSSRProcess(context.Background())
+	})
+}
+
+func (i *Inertia) ssrProcessStatus() (bool, int) {
+	i.ssrProcessMu.Lock()
+	proc := i.ssrProcess
+	i.ssrProcessMu.Unlock()
+
+	if proc == nil {
+		return false, 0
+	}
+	return proc.status()
+}
+
+// ssrProcess supervises a single SSR server process.
+type ssrProcess struct {
+	cfg     SSRProcessConfig
+	network string
+	addr    string
+	logger  Logger
+
+	ctx    context.Context //nolint:containedctx // lifetime of the supervisor, cancelled by stop
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	running  bool
+	restarts int
+}
+
+func newSSRProcess(cfg SSRProcessConfig, ssrURL string, logger Logger) (*ssrProcess, error) {
+	if cfg.StartTimeout <= 0 {
+		cfg.StartTimeout = DefaultSSRProcessStartTimeout
+	}
+	if cfg.StopTimeout <= 0 {
+		cfg.StopTimeout = DefaultSSRProcessStopTimeout
+	}
+	if cfg.RestartBackoff <= 0 {
+		cfg.RestartBackoff = DefaultSSRProcessRestartBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultSSRProcessMaxBackoff
+	}
+
+	network, addr := "tcp", cfg.Addr
+	if addr == "" {
+		var err error
+		network, addr, err = ssrURLAddr(ssrURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ssrProcess{
+		cfg:     cfg,
+		network: network,
+		addr:    addr,
+		logger:  logger,
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}, nil
+}
+
+func (p *ssrProcess) start() {
+	go p.supervise()
+}
+
+func (p *ssrProcess) stop(ctx context.Context) error {
+	p.cancel()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for SSR process to stop: %w", ctx.Err())
+	}
+}
+
+func (p *ssrProcess) status() (bool, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.running, p.restarts
+}
+
+// waitReady polls the address until it accepts connections.
+func (p *ssrProcess) waitReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.StartTimeout)
+	defer cancel()
+
+	dialer := net.Dialer{Timeout: ssrProcessReadyPollInterval}
+	ticker := time.NewTicker(ssrProcessReadyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		conn, err := dialer.DialContext(ctx, p.network, p.addr)
+		if err == nil {
+			_ = conn.Close()
+			p.logger.InfoContext(ctx, "SSR process is ready", "addr", p.addr)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("SSR process did not listen on %s: %w", p.addr, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ssrProcess) supervise() {
+	defer close(p.done)
+
+	backoff := p.cfg.RestartBackoff
+	for {
+		startedAt := time.Now()
+		err := p.run()
+		if p.ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(startedAt) >= p.cfg.MaxBackoff {
+			backoff = p.cfg.RestartBackoff
+		}
+		p.logger.ErrorContext(p.ctx, "SSR process exited, restarting",
+			"error", err,
+			"backoff", backoff.String(),
+		)
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, p.cfg.MaxBackoff)
+
+		p.mu.Lock()
+		p.restarts++
+		p.mu.Unlock()
+	}
+}
+
+// run starts the process and blocks until it exits or the supervisor is stopped.
+func (p *ssrProcess) run() error {
+	// #nosec G204 - the command comes from the application configuration
+	cmd := exec.CommandContext(p.ctx, p.cfg.Command, p.cfg.Args...)
+	cmd.Dir = p.cfg.Dir
+	cmd.Env = append(os.Environ(), p.cfg.Env...)
+	cmd.Stdout = &ssrLogWriter{logger: p.logger, stream: "stdout"}
+	cmd.Stderr = &ssrLogWriter{logger: p.logger, stream: "stderr"}
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = p.cfg.StopTimeout
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start SSR process: %w", err)
+	}
+	p.logger.InfoContext(p.ctx, "SSR process started", "pid", cmd.Process.Pid, "command", p.cfg.Command)
+
+	p.mu.Lock()
+	p.running = true
+	p.mu.Unlock()
+
+	err := cmd.Wait()
+
+	p.mu.Lock()
+	p.running = false
+	p.mu.Unlock()
+
+	return err
+}
+
+// ssrLogWriter forwards process output to the Logger line by line.
+type ssrLogWriter struct {
+	logger Logger
+	stream string
+	buf    []byte
+}
+
+func (w *ssrLogWriter) Write(data []byte) (int, error) {
+	w.buf = append(w.buf, data...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		line := string(bytes.TrimRight(w.buf[:idx], "\r"))
+		w.buf = w.buf[idx+1:]
+		if line == "" {
+			continue
+		}
+		if w.stream == "stderr" {
+			w.logger.WarnContext(context.Background(), "SSR process output", "stream", w.stream, "line", line)
+		} else {
+			w.logger.InfoContext(context.Background(), "SSR process output", "stream", w.stream, "line", line)
+		}
+	}
+	return len(data), nil
+}
+
+// ssrURLAddr returns the network and the address of the SSR render URL.
+func ssrURLAddr(raw string) (string, string, error) {
+	parsed, err := url.Parse(raw)
+	if err == nil && parsed.Scheme == ssrSchemeUnix && parsed.Path != "" {
+		return "unix", parsed.Path, nil
+	}
+	if err != nil || parsed.Host == "" || parsed.Scheme == ssrSchemeStdio {
+		return "", "", fmt.Errorf("invalid SSR URL %q for SSR process address", raw)
+	}
+	if parsed.Port() != "" {
+		return "tcp", parsed.Host, nil
+	}
+	port := "80"
+	if parsed.Scheme == "https" {
+		port = "443"
+	}
+	return "tcp", net.JoinHostPort(parsed.Hostname(), port), nil
+}
@@ -0,0 +1,153 @@
+package goinertia_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+	"github.com/assurrussa/goinertia/inertiat/fibert"
+)
+
+const ssrStubAddrEnv = "GOINERTIA_SSR_STUB_ADDR"
+
+// TestSSRProcessStub is not a real test: it is the SSR server executable spawned by the process tests.
+func TestSSRProcessStub(t *testing.T) {
+	addr := os.Getenv(ssrStubAddrEnv)
+	if addr == "" {
+		t.Skip("helper process for SSR process tests")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /render", func(w http.ResponseWriter, r *http.Request) {
+		var page goinertia.PageDTO
+		if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(goinertia.SsrDTO{Head: []string{}, Body: "<div>stub:" + page.Component + "</div>"})
+	})
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	})
+	mux.HandleFunc("POST /exit", func(http.ResponseWriter, *http.Request) {
+		os.Exit(3)
+	})
+
+	fmt.Fprintln(os.Stdout, "ssr stub listening on", addr) //nolint:forbidigo // output is forwarded to the logger
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: time.Second}
+	_ = server.ListenAndServe()
+	os.Exit(0)
+}
+
+func TestInertia_SSRProcess(t *testing.T) {
+	t.Parallel()
+
+	addr := freeAddr(t)
+	logs := &syncBuffer{}
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithLogger(goinertia.NewLoggerAdapter(slog.New(slog.NewTextHandler(logs, nil)))),
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL: "http://" + addr + "/render",
+			Process: &goinertia.SSRProcessConfig{
+				Command:        os.Args[0],
+				Args:           []string{"-test.run=^TestSSRProcessStub$"},
+				Env:            []string{ssrStubAddrEnv + "=" + addr},
+				RestartBackoff: 10 * time.Millisecond,
+				StopTimeout:    time.Second,
+			},
+		}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	ctx := context.Background()
+	require.NoError(t, ta.Inrt.StartSSRProcess(ctx))
+	t.Cleanup(func() { _ = ta.Inrt.StopSSRProcess(ctx) })
+	require.ErrorIs(t, ta.Inrt.StartSSRProcess(ctx), goinertia.ErrSSRProcessRunning)
+
+	c := fibert.Default()
+	require.NoError(t, ta.Inrt.Render(c, "Home", nil))
+	assert.Contains(t, string(c.Response().Body()), "<div>stub:Home</div>")
+	assert.True(t, ta.Inrt.SSRStatus().ProcessRunning)
+
+	// A crash is followed by a restart.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+addr+"/exit", nil)
+	require.NoError(t, err)
+	if resp, err := http.DefaultClient.Do(req); err == nil {
+		_ = resp.Body.Close()
+	}
+	require.Eventually(t, func() bool {
+		status := ta.Inrt.SSRStatus()
+		return status.ProcessRestarts >= 1 && status.ProcessRunning && dialable(addr)
+	}, 10*time.Second, 20*time.Millisecond)
+
+	c = fibert.Default()
+	require.NoError(t, ta.Inrt.Render(c, "Users", nil))
+	assert.Contains(t, string(c.Response().Body()), "<div>stub:Users</div>")
+
+	require.NoError(t, ta.Inrt.StopSSRProcess(ctx))
+	assert.False(t, ta.Inrt.SSRStatus().ProcessRunning)
+	assert.False(t, dialable(addr))
+
+	assert.Contains(t, logs.String(), "ssr stub listening on")
+	assert.Contains(t, logs.String(), "SSR process exited, restarting")
+}
+
+func TestInertia_SSRProcess_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	inrt := inertiat.NewForTest("http://localhost.loc:3000")
+	require.ErrorIs(t, inrt.StartSSRProcess(context.Background()), goinertia.ErrSSRProcessNotConfigured)
+	require.NoError(t, inrt.StopSSRProcess(context.Background()))
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	return addr
+}
+
+func dialable(addr string) bool {
+	conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,86 @@
+package goinertia
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+)
+
+// ssrStreamContext is the requestContext used inside a response stream.
+// Only the contexts are safe to use there; the rest of the request may already be released.
+type ssrStreamContext struct {
+	requestContext
+	ctx context.Context //nolint:containedctx // outlives the handler for the duration of the stream
+}
+
+func (s ssrStreamContext) RequestContext() context.Context { return s.ctx }
+
+func (s ssrStreamContext) UserContext() context.Context { return s.ctx }
+
+// renderHTMLStream sends the root template up to the first use of .processSSR, then renders the page on the
+// SSR server and sends the rest. It reports false when the template does not use .processSSR.
+func (i *Inertia) renderHTMLStream(
+	c requestContext,
+	rootTemplate *template.Template,
+	viewData map[string]any,
+	page *PageDTO,
+) (bool, error) {
+	headMarker, bodyMarker := ssrStreamMarker(), ssrStreamMarker()
+
+	var shell bytes.Buffer
+	viewData["processSSR"] = &SsrDTO{Head: []string{headMarker}, Body: bodyMarker}
+	if err := rootTemplate.Execute(&shell, viewData); err != nil {
+		return false, fmt.Errorf("error executing template: %w", err)
+	}
+
+	split := bytes.Index(shell.Bytes(), []byte(headMarker))
+	if split < 0 {
+		split = bytes.Index(shell.Bytes(), []byte(bodyMarker))
+	}
+	if split < 0 {
+		return false, nil
+	}
+	prefix := shell.Bytes()[:split]
+
+	sc := ssrStreamContext{requestContext: c, ctx: context.WithoutCancel(c.UserContext())}
+	return true, c.SendStream(func(w streamWriter) error {
+		if _, err := w.Write(prefix); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		ssr, err := i.processSSR(sc, page)
+		if err != nil {
+			i.reportSSRFallback(sc, page, err)
+			ssr = nil
+		}
+		viewData["processSSR"] = ssr
+
+		var rest bytes.Buffer
+		if err := rootTemplate.Execute(&rest, viewData); err != nil {
+			i.logger.ErrorContext(sc.ctx, "SSR stream template failed", "error", err)
+			return err
+		}
+		if !bytes.HasPrefix(rest.Bytes(), prefix) {
+			i.logger.ErrorContext(sc.ctx, "SSR stream: the root template output before .processSSR depends on it",
+				"component", page.Component,
+			)
+		}
+		if _, err := w.Write(rest.Bytes()[min(len(prefix), rest.Len()):]); err != nil {
+			return err
+		}
+		return w.Flush()
+	})
+}
+
+// ssrStreamMarker returns a random placeholder that cannot occur in the template output.
+func ssrStreamMarker() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return "inertia-ssr-" + hex.EncodeToString(buf)
+}
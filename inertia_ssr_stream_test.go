@@ -0,0 +1,132 @@
+package goinertia_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+)
+
+const streamTemplate = `<html><head><title>App</title>` +
+	`{{ if .processSSR }}{{ range .processSSR.Head }}{{ raw . }}{{ end }}{{ end }}</head>` +
+	`<body>{{ if .processSSR }}{{ raw .processSSR.Body }}` +
+	`{{ else }}<div id="app" data-page="{{ marshal .page }}"></div>{{ end }}</body></html>`
+
+func TestInertia_SSR_Streaming(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	client := &mockSSRClient{
+		onPost: func(context.Context) (int, []byte, error) {
+			<-release
+			return http.StatusOK, []byte(`{"head":["<meta name=\"ssr\">"],"body":"<div id=\"app\">SSR</div>"}`), nil
+		},
+	}
+
+	adapter := goinertia.NewHTTPAdapter(inertiat.NewForTest("http://localhost.loc:3000",
+		goinertia.WithSSRConfig(goinertia.SSRConfig{URL: "http://ssr.local", SSRClient: client, Streaming: true}),
+		goinertia.WithFS(os.DirFS(createStreamTemplate(t))),
+		goinertia.WithRootTemplate("stream.gohtml"),
+	))
+	server := httptest.NewServer(adapter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, adapter.Render(w, r, "Home", nil))
+	})))
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The head arrives while the SSR request is still blocked.
+	reader := bufio.NewReader(resp.Body)
+	head := make([]byte, len("<html><head><title>App</title>"))
+	_, err = io.ReadFull(reader, head)
+	require.NoError(t, err)
+	assert.Equal(t, "<html><head><title>App</title>", string(head))
+
+	close(release)
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, `<meta name="ssr"></head><body><div id="app">SSR</div></body></html>`, string(rest))
+}
+
+func TestInertia_SSR_Streaming_Fallback(t *testing.T) {
+	t.Parallel()
+
+	var fallbackErr error
+	client := &mockSSRClient{
+		onPost: func(context.Context) (int, []byte, error) {
+			return http.StatusBadGateway, nil, nil
+		},
+	}
+
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:            "http://ssr.local",
+			SSRClient:      client,
+			DisableRetries: true,
+			Streaming:      true,
+			OnFallback: func(_ context.Context, _ string, err error) {
+				fallbackErr = err
+			},
+		}),
+		goinertia.WithFS(os.DirFS(createStreamTemplate(t))),
+		goinertia.WithRootTemplate("stream.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	resp, body := ta.DoGet(func(c fiber.Ctx) error {
+		return ta.Inrt.Render(c, "Home", map[string]any{"title": "Hello"})
+	}, nil)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, strings.HasPrefix(body, "<html><head><title>App</title></head><body><div id=\"app\" data-page="))
+	assert.Contains(t, body, "Hello")
+	assert.Equal(t, 1, strings.Count(body, "<title>"))
+	require.ErrorIs(t, fallbackErr, goinertia.ErrBadSsrStatusCode)
+}
+
+func TestInertia_SSR_Streaming_TemplateWithoutSSR(t *testing.T) {
+	t.Parallel()
+
+	client := &mockSSRClient{
+		onPost: func(context.Context) (int, []byte, error) {
+			return http.StatusOK, []byte(`{"head":[],"body":"<div>SSR</div>"}`), nil
+		},
+	}
+
+	// The default root template is rendered without streaming when it never uses .processSSR.
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{URL: "http://ssr.local", SSRClient: client, Streaming: true}),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	resp, body := ta.DoGet(func(c fiber.Ctx) error {
+		return ta.Inrt.Render(c, "Home", nil)
+	}, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `<div id="app" data-page=`)
+}
+
+func createStreamTemplate(t *testing.T) string {
+	t.Helper()
+
+	dir := createSSRTemplates(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stream.gohtml"), []byte(streamTemplate), 0o600))
+	return dir
+}
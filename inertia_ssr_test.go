@@ -2,9 +2,12 @@ package goinertia_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -168,6 +171,369 @@ func TestInertia_SSR_Timeout(t *testing.T) {
 	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
 }
 
+func TestInertia_SSR_FallbackToCSR(t *testing.T) {
+	t.Parallel()
+
+	var (
+		fallbackComponent string
+		fallbackErr       error
+	)
+	client := &mockSSRClient{
+		onPost: func(_ context.Context) (int, []byte, error) {
+			return http.StatusBadGateway, nil, nil
+		},
+	}
+
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:            "http://ssr.local",
+			SSRClient:      client,
+			DisableRetries: true,
+			FallbackToCSR:  true,
+			OnFallback: func(_ context.Context, component string, err error) {
+				fallbackComponent = component
+				fallbackErr = err
+			},
+		}),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	c := fibert.Default()
+	err := ta.Inrt.Render(c, "Admin/Dashboard", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, c.Response().StatusCode())
+	assert.Contains(t, string(c.Response().Body()), `<div id="app" data-page=`)
+	assert.Equal(t, "Admin/Dashboard", fallbackComponent)
+	require.ErrorIs(t, fallbackErr, goinertia.ErrBadSsrStatusCode)
+}
+
+func TestInertia_SSR_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	client := &mockSSRClient{
+		onPost: func(_ context.Context) (int, []byte, error) {
+			atomic.AddInt32(&attempts, 1)
+			return http.StatusServiceUnavailable, nil, nil
+		},
+	}
+
+	var fallbacks []error
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:           "http://ssr.local",
+			SSRClient:     client,
+			MaxRetries:    3,
+			FallbackToCSR: true,
+			OnFallback: func(_ context.Context, _ string, err error) {
+				fallbacks = append(fallbacks, err)
+			},
+			CircuitBreaker: goinertia.SSRCircuitBreakerConfig{
+				FailureThreshold: 2,
+				OpenTimeout:      time.Hour,
+			},
+		}),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+	assert.Equal(t, goinertia.SSRStatus{
+		Enabled:   true,
+		Circuit:   goinertia.SSRCircuitClosed,
+		Healthy:   true,
+		Endpoints: []goinertia.SSREndpointStatus{{URL: "http://ssr.local", Healthy: true}},
+	}, ta.Inrt.SSRStatus())
+
+	// The breaker opens after two failed attempts and stops the remaining retries.
+	require.NoError(t, ta.Inrt.Render(fibert.Default(), "Home", nil))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+
+	status := ta.Inrt.SSRStatus()
+	assert.Equal(t, goinertia.SSRCircuitOpen, status.Circuit)
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.False(t, status.OpenedAt.IsZero())
+	assert.False(t, status.Healthy)
+	assert.False(t, ta.Inrt.SSRHealthy())
+
+	// While open, renders skip SSR without calling the client.
+	c := fibert.Default()
+	require.NoError(t, ta.Inrt.Render(c, "Home", nil))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.Contains(t, string(c.Response().Body()), `<div id="app" data-page=`)
+
+	require.Len(t, fallbacks, 2)
+	require.ErrorIs(t, fallbacks[0], goinertia.ErrSSRCircuitOpen)
+	require.ErrorIs(t, fallbacks[1], goinertia.ErrSSRCircuitOpen)
+}
+
+func TestInertia_SSR_CacheExcludeProps(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	client := &mockSSRClient{
+		onPost: func(_ context.Context) (int, []byte, error) {
+			atomic.AddInt32(&attempts, 1)
+			return http.StatusOK, []byte(`{"body":"<div id=\"app\" data-page=\"{}\"><h1>Home</h1></div>","head":[]}`), nil
+		},
+	}
+
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:               "http://ssr.local",
+			SSRClient:         client,
+			CacheTTL:          time.Minute,
+			CacheMaxEntries:   10,
+			CacheExcludeProps: []string{"requestedAt"},
+		}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	require.NoError(t, ta.Inrt.Render(fibert.Default(), "Home", map[string]any{"requestedAt": "first"}))
+
+	c := fibert.Default()
+	require.NoError(t, ta.Inrt.Render(c, "Home", map[string]any{"requestedAt": "second"}))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	assert.Contains(t, string(c.Response().Body()), "<h1>Home</h1>")
+	assert.Contains(t, string(c.Response().Body()), "second")
+	assert.NotContains(t, string(c.Response().Body()), "first")
+
+	require.NoError(t, ta.Inrt.Render(fibert.Default(), "Home", map[string]any{"title": "changed"}))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestInertia_SSR_Coalescing(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	release := make(chan struct{})
+	client := &mockSSRClient{
+		onPost: func(_ context.Context) (int, []byte, error) {
+			atomic.AddInt32(&attempts, 1)
+			<-release
+			return http.StatusOK, []byte(`{"body":"<div id=\"app\" data-page=\"{}\"><h1>Home</h1></div>","head":[]}`), nil
+		},
+	}
+
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:               "http://ssr.local",
+			SSRClient:         client,
+			Timeout:           5 * time.Second,
+			CacheTTL:          time.Minute,
+			CacheExcludeProps: []string{"requestId"},
+		}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	// A waiter whose request is cancelled stops waiting without failing the shared render.
+	cancelled := fibert.Default()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled.SetContext(ctx)
+	cancelledErr := make(chan error, 1)
+	go func() { cancelledErr <- ta.Inrt.Render(cancelled, "Home", nil) }()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&attempts) == 1 }, 5*time.Second, time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-cancelledErr, context.Canceled)
+
+	const waiters = 5
+	bodies := make([]string, waiters)
+	var wg sync.WaitGroup
+	for n := range waiters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := fibert.Default()
+			assert.NoError(t, ta.Inrt.Render(c, "Home", map[string]any{"requestId": fmt.Sprintf("req-%d", n)}))
+			bodies[n] = string(c.Response().Body())
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	for n, body := range bodies {
+		assert.Contains(t, body, "<h1>Home</h1>")
+		assert.Contains(t, body, fmt.Sprintf("req-%d", n))
+	}
+}
+
+func TestInertia_SSR_StaleWhileRevalidate(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	client := &mockSSRClient{
+		onPost: func(_ context.Context) (int, []byte, error) {
+			val := atomic.AddInt32(&attempts, 1)
+			return http.StatusOK, fmt.Appendf(nil, `{"body":"<h1>Render %d</h1>","head":[]}`, val), nil
+		},
+	}
+
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:                  "http://ssr.local",
+			SSRClient:            client,
+			CacheTTL:             20 * time.Millisecond,
+			StaleWhileRevalidate: time.Minute,
+		}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	render := func() string {
+		t.Helper()
+		c := fibert.Default()
+		require.NoError(t, ta.Inrt.Render(c, "Home", nil))
+		return string(c.Response().Body())
+	}
+
+	assert.Contains(t, render(), "<h1>Render 1</h1>")
+	time.Sleep(30 * time.Millisecond)
+
+	// The stale entry is served right away and refreshed in the background.
+	assert.Contains(t, render(), "<h1>Render 1</h1>")
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&attempts) == 2 }, 5*time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return strings.Contains(render(), "<h1>Render 2</h1>") },
+		5*time.Second, time.Millisecond)
+
+	stats := ta.Inrt.SSRCacheStats()
+	assert.Equal(t, uint64(1), stats.StaleHits)
+	assert.Equal(t, uint64(1), stats.Misses)
+}
+
+func TestInertia_SSR_StaleWhileRevalidate_MaxStale(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	client := &mockSSRClient{
+		onPost: func(_ context.Context) (int, []byte, error) {
+			val := atomic.AddInt32(&attempts, 1)
+			return http.StatusOK, fmt.Appendf(nil, `{"body":"<h1>Render %d</h1>","head":[]}`, val), nil
+		},
+	}
+
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:                  "http://ssr.local",
+			SSRClient:            client,
+			CacheTTL:             10 * time.Millisecond,
+			StaleWhileRevalidate: 10 * time.Millisecond,
+		}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	require.NoError(t, ta.Inrt.Render(fibert.Default(), "Home", nil))
+	time.Sleep(40 * time.Millisecond)
+
+	// Past the stale window the render waits for a fresh result.
+	c := fibert.Default()
+	require.NoError(t, ta.Inrt.Render(c, "Home", nil))
+	assert.Contains(t, string(c.Response().Body()), "<h1>Render 2</h1>")
+	assert.Zero(t, ta.Inrt.SSRCacheStats().StaleHits)
+}
+
+func TestInertia_SSR_URLsFailover(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var calls []string
+	client := &urlSSRClient{
+		onPost: func(url string) (int, []byte, error) {
+			mu.Lock()
+			calls = append(calls, url)
+			mu.Unlock()
+			if url == "http://ssr-a.local/render" {
+				return http.StatusServiceUnavailable, nil, nil
+			}
+			return http.StatusOK, []byte(`{"body":"<h1>From B</h1>","head":[]}`), nil
+		},
+	}
+
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URLs:             []string{"http://ssr-a.local/render", "http://ssr-b.local/render"},
+			SSRClient:        client,
+			EndpointCooldown: time.Minute,
+		}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+	require.True(t, ta.Inrt.IsSSREnabled())
+
+	// The first request fails on A and is retried on B.
+	c := fibert.Default()
+	require.NoError(t, ta.Inrt.Render(c, "Home", nil))
+	assert.Contains(t, string(c.Response().Body()), "<h1>From B</h1>")
+	assert.Equal(t, []string{"http://ssr-a.local/render", "http://ssr-b.local/render"}, calls)
+
+	// A is in cooldown, so the following requests go straight to B.
+	require.NoError(t, ta.Inrt.Render(fibert.Default(), "Users", nil))
+	require.NoError(t, ta.Inrt.Render(fibert.Default(), "Posts", nil))
+	assert.Equal(t, []string{"http://ssr-b.local/render", "http://ssr-b.local/render"}, calls[2:])
+
+	assert.Equal(t, []goinertia.SSREndpointStatus{
+		{URL: "http://ssr-a.local/render", Healthy: false},
+		{URL: "http://ssr-b.local/render", Healthy: true},
+	}, ta.Inrt.SSRStatus().Endpoints)
+}
+
+func TestInertia_SSR_ComponentSelection(t *testing.T) {
+	t.Parallel()
+
+	var rendered []string
+	client := &mockSSRClient{
+		onPost: func(context.Context) (int, []byte, error) {
+			return http.StatusOK, []byte(`{"body":"<h1>SSR</h1>","head":[]}`), nil
+		},
+	}
+
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{
+			URL:               "http://ssr.local",
+			SSRClient:         client,
+			Components:        []string{"Public/*", "Home"},
+			ExcludeComponents: []string{"Public/Drafts/*"},
+		}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	render := func(component string, ssr *bool) {
+		c := fibert.Default()
+		if ssr != nil {
+			ta.Inrt.WithSSR(c, *ssr)
+		}
+		require.NoError(t, ta.Inrt.Render(c, component, nil))
+		if strings.Contains(string(c.Response().Body()), "<h1>SSR</h1>") {
+			rendered = append(rendered, component)
+		}
+	}
+
+	enabled, disabled := true, false
+	render("Home", nil)
+	render("Public/Posts/Index", nil)
+	render("Public/Drafts/Edit", nil)
+	render("Admin/Dashboard", nil)
+	render("Admin/Reports", &enabled)
+	render("Public/About", &disabled)
+
+	assert.Equal(t, []string{"Home", "Public/Posts/Index", "Admin/Reports"}, rendered)
+}
+
 func createSSRTemplates(t *testing.T) string {
 	t.Helper()
 
@@ -195,3 +561,13 @@ func (m *mockSSRClient) Post(ctx context.Context, _ string, _ []byte, _ map[stri
 	}
 	return m.onPost(ctx)
 }
+
+type urlSSRClient struct {
+	onPost func(url string) (int, []byte, error)
+}
+
+func (m *urlSSRClient) Reset() {}
+
+func (m *urlSSRClient) Post(_ context.Context, url string, _ []byte, _ map[string]string) (int, []byte, error) {
+	return m.onPost(url)
+}
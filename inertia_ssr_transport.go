@@ -0,0 +1,397 @@
+package goinertia
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	ssrSchemeUnix  = "unix"
+	ssrSchemeStdio = "stdio"
+
+	// ssrUnixDefaultPath is the HTTP path requested over a unix socket without a "path" query parameter.
+	ssrUnixDefaultPath = "/render"
+	// ssrStdioMaxFrame bounds a response frame, so a corrupt length cannot allocate unbounded memory.
+	ssrStdioMaxFrame = 64 << 20
+)
+
+// ErrSSRStdioFrameTooLarge is returned when an stdio SSR process announces a frame above 64 MiB.
+var ErrSSRStdioFrameTooLarge = errors.New("inertia: SSR stdio frame too large")
+
+// ssrURLScheme returns the lower-case scheme of an SSR URL, or "" when it cannot be parsed.
+func ssrURLScheme(raw string) string {
+	scheme, _, ok := strings.Cut(raw, ":")
+	if !ok {
+		return ""
+	}
+	return strings.ToLower(scheme)
+}
+
+// unixSSRClient sends HTTP requests to an SSR server listening on a unix socket.
+// URLs have the form unix:///run/ssr.sock, with an optional ?path=/render query parameter.
+type unixSSRClient struct {
+	mu      sync.Mutex
+	clients map[string]*http.Client
+}
+
+func newUnixSSRClient() *unixSSRClient {
+	return &unixSSRClient{clients: make(map[string]*http.Client)}
+}
+
+func (c *unixSSRClient) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, client := range c.clients {
+		client.CloseIdleConnections()
+	}
+}
+
+func (c *unixSSRClient) Post(ctx context.Context, url string, body []byte, headers map[string]string) (int, []byte, error) {
+	return c.do(ctx, http.MethodPost, url, body, headers)
+}
+
+func (c *unixSSRClient) Get(ctx context.Context, url string, headers map[string]string) (int, []byte, error) {
+	return c.do(ctx, http.MethodGet, url, nil, headers)
+}
+
+func (c *unixSSRClient) do(
+	ctx context.Context,
+	method, rawURL string,
+	body []byte,
+	headers map[string]string,
+) (int, []byte, error) {
+	socket, path, err := parseUnixSSRURL(rawURL)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, "http://localhost"+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, fmt.Errorf("error creating ssr request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.client(socket).Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error reading ssr response: %w", err)
+	}
+	return resp.StatusCode, data, nil
+}
+
+// client returns the HTTP client dialing the socket, so connections are reused per socket.
+func (c *unixSSRClient) client(socket string) *http.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[socket]; ok {
+		return client
+	}
+	dialer := &net.Dialer{}
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socket)
+		},
+	}}
+	c.clients[socket] = client
+	return client
+}
+
+// parseUnixSSRURL returns the socket path and the HTTP request path of a unix:// URL.
+func parseUnixSSRURL(raw string) (string, string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Path == "" {
+		return "", "", fmt.Errorf("invalid SSR unix socket URL %q", raw)
+	}
+	return parsed.Path, unixSSRRequestPath(parsed), nil
+}
+
+func unixSSRRequestPath(parsed *url.URL) string {
+	path := parsed.Query().Get("path")
+	if path == "" {
+		return ssrUnixDefaultPath
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// stdioSSRClient renders pages with child processes speaking a length-prefixed protocol on stdin/stdout.
+// URLs have the form stdio://node?arg=public/ssr/ssr.js: the host and path are the command and every "arg"
+// query parameter is an argument. One process is started per URL on first use and restarted when it exits.
+//
+// Each request is a frame of a 4-byte big-endian length followed by the page JSON. The process answers with
+// a frame holding the SSR JSON ({"head":[...],"body":"..."}), or {"error":"..."} when rendering fails.
+// Frames are processed one at a time per process; use several URLs to render in parallel.
+type stdioSSRClient struct {
+	logger Logger
+
+	mu    sync.Mutex
+	procs map[string]*stdioSSRProcess
+}
+
+func newStdioSSRClient(logger Logger) *stdioSSRClient {
+	return &stdioSSRClient{logger: logger, procs: make(map[string]*stdioSSRProcess)}
+}
+
+// Reset keeps the processes running; a process is only replaced after it fails.
+func (c *stdioSSRClient) Reset() {}
+
+func (c *stdioSSRClient) Post(ctx context.Context, url string, body []byte, _ map[string]string) (int, []byte, error) {
+	proc, err := c.process(url)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	data, err := proc.roundTrip(ctx, body)
+	if err != nil {
+		// The stream position is unknown after a failed exchange, so the process is replaced.
+		c.discard(url, proc)
+		return 0, nil, err
+	}
+
+	var reply struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &reply); err == nil && reply.Error != "" {
+		return http.StatusInternalServerError, data, nil
+	}
+	return http.StatusOK, data, nil
+}
+
+// Get reports the process of the URL as healthy while it runs. It never starts one: the next render does.
+func (c *stdioSSRClient) Get(_ context.Context, url string, _ map[string]string) (int, []byte, error) {
+	c.mu.Lock()
+	proc, ok := c.procs[url]
+	c.mu.Unlock()
+
+	if !ok || proc.exited() {
+		return http.StatusServiceUnavailable, nil, nil
+	}
+	return http.StatusOK, nil, nil
+}
+
+// close stops every process.
+func (c *stdioSSRClient) close() {
+	c.mu.Lock()
+	procs := c.procs
+	c.procs = make(map[string]*stdioSSRProcess)
+	c.mu.Unlock()
+
+	for _, proc := range procs {
+		proc.kill()
+	}
+}
+
+func (c *stdioSSRClient) process(rawURL string) (*stdioSSRProcess, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if proc, ok := c.procs[rawURL]; ok && !proc.exited() {
+		return proc, nil
+	}
+
+	command, args, err := parseStdioSSRURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	proc, err := startStdioSSRProcess(command, args, c.logger)
+	if err != nil {
+		return nil, err
+	}
+	c.procs[rawURL] = proc
+	return proc, nil
+}
+
+func (c *stdioSSRClient) discard(rawURL string, proc *stdioSSRProcess) {
+	c.mu.Lock()
+	if c.procs[rawURL] == proc {
+		delete(c.procs, rawURL)
+	}
+	c.mu.Unlock()
+
+	proc.kill()
+}
+
+// parseStdioSSRURL returns the command and the arguments of a stdio:// URL.
+func parseStdioSSRURL(raw string) (string, []string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid SSR stdio URL %q", raw)
+	}
+	command := parsed.Opaque
+	if command == "" {
+		command = parsed.Host + parsed.Path
+	}
+	if command == "" {
+		return "", nil, fmt.Errorf("invalid SSR stdio URL %q: missing command", raw)
+	}
+	return command, parsed.Query()["arg"], nil
+}
+
+// stdioSSRProcess is a child process rendering one frame at a time.
+type stdioSSRProcess struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	// frames receives the response frames read by readFrames; it is closed when reading stops.
+	frames  chan []byte
+	readErr error
+	// done is closed when reading stops, i.e. the process exited or broke the protocol.
+	done     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+	// turn serializes exchanges; it is a channel so waiting can be cancelled.
+	turn chan struct{}
+}
+
+func startStdioSSRProcess(command string, args []string, logger Logger) (*stdioSSRProcess, error) {
+	// #nosec G204 - the command comes from the application configuration
+	cmd := exec.Command(command, args...)
+	cmd.Env = os.Environ()
+	cmd.Stderr = &ssrLogWriter{logger: logger, stream: "stderr"}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("error creating SSR process stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("error creating SSR process stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start SSR process: %w", err)
+	}
+	logger.InfoContext(context.Background(), "SSR stdio process started", "pid", cmd.Process.Pid, "command", command)
+
+	proc := &stdioSSRProcess{
+		cmd:    cmd,
+		stdin:  stdin,
+		frames: make(chan []byte, 1),
+		done:   make(chan struct{}),
+		stop:   make(chan struct{}),
+		turn:   make(chan struct{}, 1),
+	}
+	go proc.readFrames(bufio.NewReader(stdout), command, logger)
+	return proc, nil
+}
+
+// readFrames owns stdout: it reads the response frames until the process exits or sends a bad frame,
+// and only then waits for the process, as os/exec requires for StdoutPipe.
+func (p *stdioSSRProcess) readFrames(stdout *bufio.Reader, command string, logger Logger) {
+	for {
+		data, err := readSSRFrame(stdout)
+		if err != nil {
+			p.readErr = err
+			break
+		}
+		select {
+		case p.frames <- data:
+		case <-p.stop:
+		}
+	}
+	close(p.frames)
+	close(p.done)
+
+	// A process that broke the protocol may still run; it is killed when its exchange fails.
+	err := p.cmd.Wait()
+	logger.WarnContext(context.Background(), "SSR stdio process exited", "command", command, "error", err)
+}
+
+func readSSRFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("error reading ssr frame: %w", err)
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > ssrStdioMaxFrame {
+		return nil, fmt.Errorf("%w: %d bytes", ErrSSRStdioFrameTooLarge, size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("error reading ssr frame: %w", err)
+	}
+	return data, nil
+}
+
+func (p *stdioSSRProcess) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// roundTrip writes a request frame and reads the response frame.
+func (p *stdioSSRProcess) roundTrip(ctx context.Context, body []byte) ([]byte, error) {
+	select {
+	case p.turn <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-p.turn }()
+
+	type result struct {
+		data []byte
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		data, err := p.exchange(body)
+		ch <- result{data: data, err: err}
+	}()
+
+	select {
+	case res := <-ch:
+		return res.data, res.err
+	case <-ctx.Done():
+		// Killing the process unblocks the exchange.
+		p.kill()
+		<-ch
+		return nil, ctx.Err()
+	}
+}
+
+func (p *stdioSSRProcess) exchange(body []byte) ([]byte, error) {
+	frame := make([]byte, 4+len(body))
+	binary.BigEndian.PutUint32(frame, uint32(len(body))) // #nosec G115 - pages are far below 4 GiB
+	copy(frame[4:], body)
+	if _, err := p.stdin.Write(frame); err != nil {
+		return nil, fmt.Errorf("error writing ssr frame: %w", err)
+	}
+
+	data, ok := <-p.frames
+	if !ok {
+		return nil, p.readErr
+	}
+	return data, nil
+}
+
+func (p *stdioSSRProcess) kill() {
+	p.stopOnce.Do(func() { close(p.stop) })
+	_ = p.stdin.Close()
+	_ = p.cmd.Process.Kill()
+}
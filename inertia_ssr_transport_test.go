@@ -0,0 +1,149 @@
+package goinertia_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+	"github.com/assurrussa/goinertia/inertiat/fibert"
+)
+
+const ssrStdioStubArg = "ssr-stdio-stub"
+
+// TestSSRStdioStub is not a real test: it is the stdio SSR process spawned by the stdio tests.
+func TestSSRStdioStub(t *testing.T) {
+	if !slices.Contains(flag.Args(), ssrStdioStubArg) {
+		t.Skip("helper process for SSR stdio tests")
+	}
+
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(os.Stdin, header[:]); err != nil {
+			os.Exit(0)
+		}
+		frame := make([]byte, binary.BigEndian.Uint32(header[:]))
+		if _, err := io.ReadFull(os.Stdin, frame); err != nil {
+			os.Exit(1)
+		}
+
+		var page goinertia.PageDTO
+		_ = json.Unmarshal(frame, &page)
+		var reply []byte
+		switch page.Component {
+		case "Crash":
+			os.Exit(3)
+		case "Broken":
+			reply = []byte(`{"error":"render failed"}`)
+		default:
+			reply, _ = json.Marshal(goinertia.SsrDTO{Head: []string{}, Body: "<div>stdio:" + page.Component + "</div>"})
+		}
+		binary.BigEndian.PutUint32(header[:], uint32(len(reply)))
+		_, _ = os.Stdout.Write(append(header[:], reply...))
+	}
+}
+
+func TestInertia_SSR_Stdio(t *testing.T) {
+	t.Parallel()
+
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	ssrURL := (&url.URL{
+		Scheme:   "stdio",
+		Path:     exe,
+		RawQuery: url.Values{"arg": {"-test.run=^TestSSRStdioStub$", ssrStdioStubArg}}.Encode(),
+	}).String()
+
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{URL: ssrURL, DisableRetries: true}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+	t.Cleanup(ta.Inrt.DisableSSR)
+
+	render := func(component string) (string, error) {
+		c := fibert.Default()
+		err := ta.Inrt.Render(c, component, nil)
+		return string(c.Response().Body()), err
+	}
+
+	// Health probes only report on a running process; they never start one.
+	require.Error(t, ta.Inrt.CheckSSRHealth(context.Background()))
+	require.Error(t, ta.Inrt.CheckSSRHealth(context.Background()))
+
+	body, err := render("Home")
+	require.NoError(t, err)
+	assert.Contains(t, body, "<div>stdio:Home</div>")
+	require.NoError(t, ta.Inrt.CheckSSRHealth(context.Background()))
+
+	_, err = render("Broken")
+	require.ErrorIs(t, err, goinertia.ErrBadSsrStatusCode)
+
+	// A crashed process is replaced on the next request.
+	_, err = render("Crash")
+	require.Error(t, err)
+	body, err = render("Users")
+	require.NoError(t, err)
+	assert.Contains(t, body, "<div>stdio:Users</div>")
+
+	require.NoError(t, ta.Inrt.CheckSSRHealth(context.Background()))
+}
+
+func TestInertia_SSR_UnixSocket(t *testing.T) {
+	t.Parallel()
+
+	// Socket paths are limited to about 100 bytes, which t.TempDir can exceed.
+	dir, err := os.MkdirTemp("", "ssr")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "ssr.sock")
+
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /ssr/render", func(w http.ResponseWriter, r *http.Request) {
+		var page goinertia.PageDTO
+		if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(goinertia.SsrDTO{Head: []string{}, Body: "<div>unix:" + page.Component + "</div>"})
+	})
+	mux.HandleFunc("GET /ssr/health", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second}
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	tmpDir := createSSRTemplates(t)
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithSSRConfig(goinertia.SSRConfig{URL: "unix://" + socket + "?path=/ssr/render"}),
+		goinertia.WithFS(os.DirFS(tmpDir)),
+		goinertia.WithRootTemplate("ssr.gohtml"),
+	)
+	require.NoError(t, ta.Inrt.ParseTemplates())
+
+	c := fibert.Default()
+	require.NoError(t, ta.Inrt.Render(c, "Home", nil))
+	assert.Contains(t, string(c.Response().Body()), "<div>unix:Home</div>")
+
+	require.NoError(t, ta.Inrt.CheckSSRHealth(context.Background()))
+	assert.True(t, ta.Inrt.SSRHealthy())
+}
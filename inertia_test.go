@@ -178,6 +178,64 @@ func TestInertia_ErrorTemplateFallback(t *testing.T) {
 	assert.Equal(t, "Internal server error", body)
 }
 
+func TestInertia_ErrorPage(t *testing.T) {
+	t.Parallel()
+
+	ta := inertiat.NewTestAppWithErrorHandler(t, goinertia.WithErrorPage(goinertia.ErrorPageConfig{
+		Component: "Error",
+		Statuses:  []int{fiber.StatusNotFound, fiber.StatusForbidden},
+	}))
+	notFound := func(_ fiber.Ctx) error { return fiber.ErrNotFound }
+
+	//nolint:bodyclose // tests
+	resp, body := ta.DoInertiaGet(notFound, nil)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get(goinertia.HeaderInertia))
+	var page goinertia.PageDTO
+	require.NoError(t, json.Unmarshal([]byte(body), &page))
+	assert.Equal(t, "Error", page.Component)
+	assert.InDelta(t, fiber.StatusNotFound, page.Props["status"], 0)
+	assert.Equal(t, "Not Found", page.Props["message"])
+	assert.Equal(t, "Page not found", page.Props["details"])
+
+	// Full page loads keep the root error template.
+	//nolint:bodyclose // tests
+	resp, body = ta.DoGet(notFound, nil)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, body, `<div class="error-code">404</div>`)
+
+	// Other statuses and validation errors still redirect back.
+	//nolint:bodyclose // tests
+	resp, _ = ta.DoInertiaGet(func(_ fiber.Ctx) error { return errors.New("boom") }, map[string]string{"path": "/boom"})
+	assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+	//nolint:bodyclose // tests
+	resp, _ = ta.DoInertiaGet(func(_ fiber.Ctx) error {
+		return goinertia.NewValidationError(fiber.StatusNotFound, "Missing", goinertia.ValidationErrors{"id": {"unknown"}})
+	}, map[string]string{"path": "/invalid"})
+	assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+
+	// Non-GET requests redirect back as before.
+	//nolint:bodyclose // tests
+	resp, _ = ta.DoInertiaPost(notFound, nil)
+	assert.Equal(t, fiber.StatusSeeOther, resp.StatusCode)
+}
+
+func TestInertia_ErrorPage_FullPageLoads(t *testing.T) {
+	t.Parallel()
+
+	ta := inertiat.NewTestAppWithErrorHandler(t, goinertia.WithErrorPage(goinertia.ErrorPageConfig{
+		Component:     "Error",
+		FullPageLoads: true,
+	}))
+
+	//nolint:bodyclose // tests
+	resp, body := ta.DoGet(func(_ fiber.Ctx) error { return fiber.ErrForbidden }, nil)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	assert.Contains(t, body, `&#34;component&#34;:&#34;Error&#34;`)
+	assert.Contains(t, body, `&#34;status&#34;:403`)
+	assert.NotContains(t, body, `class="error-code"`)
+}
+
 func TestInertia_WithLazyProp(t *testing.T) {
 	callCount := 0
 	//nolint:unparam // tests
@@ -733,6 +791,49 @@ func TestInertia_RedirectBackWithValidationErrors(t *testing.T) {
 	assert.Equal(t, "Error 1", errs["field1"])
 }
 
+func TestInertia_RedirectBackWithValidationErrors_Full(t *testing.T) {
+	store := newTestStore()
+	adapter := goinertia.NewFiberSessionAdapter[*session.Session](store)
+	ta := inertiat.NewTestAppWithErrorHandler(t,
+		goinertia.WithSessionStore(adapter),
+		goinertia.WithFullValidationErrors(),
+	)
+
+	//nolint:bodyclose // tests
+	resp, _ := ta.DoInertiaGet(func(c fiber.Ctx) error {
+		ta.Inrt.WithError(c, "name", "Name is required")
+		return ta.Inrt.RedirectBackWithValidationErrors(c, goinertia.ValidationErrors{
+			"password": {"Too short", "Needs a digit"},
+		})
+	}, nil)
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	render := func(c fiber.Ctx) error { return ta.Inrt.Render(c, "TestComponent", nil) }
+	//nolint:bodyclose // tests
+	resp2, body := ta.DoInertiaGet(render, map[string]string{"path": "/testpage"}, resp.Cookies()...)
+	require.Equal(t, http.StatusOK, resp2.StatusCode)
+	page := inertiat.DecodePage(t, body)
+	assert.Equal(t, map[string]any{
+		"name":     []any{"Name is required"},
+		"password": []any{"Too short", "Needs a digit"},
+	}, page.Props["errors"])
+
+	// Error bags keep every message as well.
+	//nolint:bodyclose // tests
+	resp, _ = ta.DoInertiaGet(func(c fiber.Ctx) error {
+		return ta.Inrt.RedirectBackWithValidationErrors(c, goinertia.ValidationErrors{"email": {"Invalid", "Taken"}})
+	}, map[string]string{"path": "/submit"})
+	//nolint:bodyclose // tests
+	_, body = ta.DoInertiaGet(render, map[string]string{
+		"path":                   "/bagpage",
+		goinertia.HeaderErrorBag: "login",
+	}, resp.Cookies()...)
+	page = inertiat.DecodePage(t, body)
+	assert.Equal(t, map[string]any{
+		"login": map[string]any{"email": []any{"Invalid", "Taken"}},
+	}, page.Props["errors"])
+}
+
 func TestInertia_InvalidContextKeyViewData(t *testing.T) {
 	ta := inertiat.NewTestAppWithErrorHandler(t)
 
@@ -1900,6 +2001,123 @@ func TestInertia_ErrorBag(t *testing.T) {
 	assert.Equal(t, "Invalid", bag["email"])
 }
 
+func TestInertia_ErrorBag_ThroughRedirect(t *testing.T) {
+	store := newTestStore()
+	adapter := goinertia.NewFiberSessionAdapter[*session.Session](store)
+	ta := inertiat.NewTestAppWithErrorHandler(t, goinertia.WithSessionStore(adapter))
+
+	submit := func(field string) func(c fiber.Ctx) error {
+		return func(c fiber.Ctx) error {
+			ta.Inrt.WithErrors(c, map[string]string{field: "Invalid " + field})
+			return ta.Inrt.Redirect(c, "/page")
+		}
+	}
+
+	// Two forms post to different endpoints before the page is rendered again.
+	//nolint:bodyclose // tests
+	resp, _ := ta.DoInertiaPost(submit("email"), map[string]string{
+		"path":                   "/login",
+		goinertia.HeaderErrorBag: "login",
+	})
+	require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+	cookies := resp.Cookies()
+	//nolint:bodyclose // tests
+	resp, _ = ta.DoInertiaPost(submit("name"), map[string]string{
+		"path":                   "/register",
+		goinertia.HeaderErrorBag: "register",
+	}, cookies...)
+	require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+
+	// The follow-up request carries another bag name; the flashed bags are restored as they were captured.
+	//nolint:bodyclose // tests
+	resp, body := ta.DoInertiaGet(func(c fiber.Ctx) error {
+		return ta.Inrt.Render(c, "Page", nil)
+	}, map[string]string{
+		"path":                   "/page",
+		goinertia.HeaderErrorBag: "register",
+	}, cookies...)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	page := inertiat.DecodePage(t, body)
+	assert.Equal(t, map[string]any{
+		"login":    map[string]any{"email": "Invalid email"},
+		"register": map[string]any{"name": "Invalid name"},
+	}, page.Props["errors"])
+}
+
+func TestInertia_ErrorBag_RestoredWithRenderErrors(t *testing.T) {
+	store := newTestStore()
+	adapter := goinertia.NewFiberSessionAdapter[*session.Session](store)
+	ta := inertiat.NewTestAppWithErrorHandler(t, goinertia.WithSessionStore(adapter))
+
+	//nolint:bodyclose // tests
+	resp, _ := ta.DoInertiaPost(func(c fiber.Ctx) error {
+		ta.Inrt.WithErrors(c, map[string]string{"email": "Invalid email"})
+		return ta.Inrt.Redirect(c, "/page")
+	}, map[string]string{
+		"path":                   "/login",
+		goinertia.HeaderErrorBag: "login",
+	})
+	require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+	cookies := resp.Cookies()
+
+	// Errors added while rendering join the bag of the request; the restored bag is kept.
+	//nolint:bodyclose // tests
+	resp, body := ta.DoInertiaGet(func(c fiber.Ctx) error {
+		ta.Inrt.WithError(c, "notice", "Check your data")
+		return ta.Inrt.Render(c, "Page", nil)
+	}, map[string]string{
+		"path":                   "/page",
+		goinertia.HeaderErrorBag: "register",
+	}, cookies...)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	page := inertiat.DecodePage(t, body)
+	assert.Equal(t, map[string]any{
+		"login":    map[string]any{"email": "Invalid email"},
+		"register": map[string]any{"notice": "Check your data"},
+	}, page.Props["errors"])
+}
+
+func TestInertia_ErrorBag_KeptByRedirectWithOtherBag(t *testing.T) {
+	store := newTestStore()
+	adapter := goinertia.NewFiberSessionAdapter[*session.Session](store)
+	ta := inertiat.NewTestAppWithErrorHandler(t, goinertia.WithSessionStore(adapter))
+
+	//nolint:bodyclose // tests
+	resp, _ := ta.DoInertiaPost(func(c fiber.Ctx) error {
+		ta.Inrt.WithErrors(c, map[string]string{"email": "Invalid email"})
+		return ta.Inrt.Redirect(c, "/page")
+	}, map[string]string{
+		"path":                   "/login",
+		goinertia.HeaderErrorBag: "login",
+	})
+	require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+	cookies := resp.Cookies()
+
+	// Another form redirects before the page is shown; its bag must not drop the login bag.
+	//nolint:bodyclose // tests
+	resp, _ = ta.DoInertiaPost(func(c fiber.Ctx) error {
+		ta.Inrt.WithErrors(c, map[string]string{"name": "Invalid name"})
+		return ta.Inrt.Redirect(c, "/page")
+	}, map[string]string{
+		"path":                   "/profile",
+		goinertia.HeaderErrorBag: "profile",
+	}, cookies...)
+	require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+
+	//nolint:bodyclose // tests
+	resp, body := ta.DoInertiaGet(func(c fiber.Ctx) error {
+		return ta.Inrt.Render(c, "Page", nil)
+	}, map[string]string{
+		"path": "/page",
+	}, cookies...)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	page := inertiat.DecodePage(t, body)
+	assert.Equal(t, map[string]any{
+		"login":   map[string]any{"email": "Invalid email"},
+		"profile": map[string]any{"name": "Invalid name"},
+	}, page.Props["errors"])
+}
+
 func TestInertia_ResetMergeProps(t *testing.T) {
 	ta := inertiat.NewTestAppWithoutMiddleware(t)
 
@@ -3,6 +3,7 @@ package goinertia
 import (
 	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/gofiber/fiber/v3"
 )
@@ -24,7 +25,7 @@ func (i *Inertia) Middleware() fiber.Handler {
 		}
 
 		// Check asset version for GET requests only
-		if method == http.MethodGet && c.Get(HeaderVersion) != i.assetVersion && !i.isPrecognitionRequest(c) {
+		if method == http.MethodGet && c.Get(HeaderVersion) != i.currentAssetVersion() && !IsPrecognition(c) {
 			c.Set(HeaderLocation, buildInertiaLocation(i.baseURLParsed, c.OriginalURL()))
 			return c.SendStatus(fiber.StatusConflict)
 		}
@@ -40,17 +41,21 @@ func (i *Inertia) MiddlewareErrorListener() fiber.ErrorHandler {
 	return func(c fiber.Ctx, err error) error {
 		isAllowedErrorDetailsMessage := i.canExposeDetails(c, c.GetHeaders())
 		errReturn := getError(isAllowedErrorDetailsMessage, err, i.customErrorGettingHandler)
-		if i.isPrecognitionRequest(c) {
-			return i.renderPrecognitionError(c, errReturn)
+		if IsPrecognition(c) {
+			return i.renderPrecognitionError(i.fiberCtx(c), errReturn)
 		}
-		details := i.customErrorDetailsHandler(errReturn, isAllowedErrorDetailsMessage)
+		details := i.translateText(i.fiberCtx(c), i.customErrorDetailsHandler(errReturn, isAllowedErrorDetailsMessage))
 
+		if i.shouldRenderErrorPage(c, errReturn) {
+			return i.renderErrorPage(c, errReturn, details)
+		}
 		if c.Get(HeaderInertia) == "" && c.Method() == fiber.MethodGet {
 			return i.renderHTMLError(c, errReturn, details)
 		}
 
 		i.WithValidationErrors(c, errReturn.ValidationErrors())
-		i.WithFlashMessages(c, errReturn.FlashErrors()...)
+		i.captureOldInput(c, errReturn)
+		i.WithFlashMessages(c, i.translateFlashErrors(i.fiberCtx(c), errReturn.FlashErrors())...)
 		if len(errReturn.ValidationErrors()) == 0 && details != "" {
 			i.WithFlashError(c, details)
 		}
@@ -60,20 +65,73 @@ func (i *Inertia) MiddlewareErrorListener() fiber.ErrorHandler {
 	}
 }
 
+// ErrorPageConfig renders errors as an Inertia page component (see WithErrorPage).
+type ErrorPageConfig struct {
+	// Component is the page component, e.g. "Error". It receives the "status" and "message" props,
+	// and "details" when the error details are not empty.
+	Component string
+	// Statuses limits the component to these response statuses. Default: every status from 400.
+	Statuses []int
+	// SSR renders the component on the SSR server when SSR is enabled. Default: client-side only.
+	SSR bool
+	// FullPageLoads also replaces the root error template for non-Inertia GET requests.
+	FullPageLoads bool
+}
+
+// shouldRenderErrorPage reports whether the error is rendered as the error component.
+// Validation errors and non-GET requests keep redirecting back, so forms can show the errors.
+func (i *Inertia) shouldRenderErrorPage(c fiber.Ctx, errReturn *Error) bool {
+	cfg := i.errorPage
+	if cfg.Component == "" || c.Method() != fiber.MethodGet || len(errReturn.ValidationErrors()) > 0 {
+		return false
+	}
+	if c.Get(HeaderInertia) == "" && !cfg.FullPageLoads {
+		return false
+	}
+	if len(cfg.Statuses) == 0 {
+		return errReturn.Code >= fiber.StatusBadRequest
+	}
+	return slices.Contains(cfg.Statuses, errReturn.Code)
+}
+
+// renderErrorPage renders the error component with the error status. If that fails, the root error template
+// is rendered instead.
+func (i *Inertia) renderErrorPage(c fiber.Ctx, errReturn *Error, details string) error {
+	rc := i.fiberCtx(c)
+	if !i.errorPage.SSR {
+		i.withSSR(rc, false)
+	}
+
+	props := map[string]any{
+		"status":  errReturn.Code,
+		"message": i.translateText(rc, errReturn.Message),
+	}
+	if details != "" {
+		props["details"] = details
+	}
+
+	c.Status(errReturn.Code)
+	if err := i.render(rc, i.errorPage.Component, props); err != nil {
+		i.logger.ErrorContext(c, "error rendering error page component",
+			"component", i.errorPage.Component,
+			"error", err,
+		)
+		c.Response().Header.Del(HeaderInertia)
+		return i.renderHTMLError(c, errReturn, details)
+	}
+
+	return i.redirectCheck(c, nil)
+}
+
 func (i *Inertia) redirectCheck(c fiber.Ctx, err error) error {
-	i.setFlashSessionData(c)
+	rc := i.fiberCtx(c)
+	i.setFlashSessionData(rc)
 
 	if c.Get(HeaderInertia) == "" {
 		return err
 	}
 
-	addVaryHeader(c, HeaderInertia)
-	if i.precognitionVary {
-		addVaryHeader(c, HeaderPrecognition)
-	}
-	if i.shouldNoCacheResponse(c) {
-		c.Set(fiber.HeaderCacheControl, "no-cache")
-	}
+	i.addInertiaResponseHeaders(rc)
 
 	method := c.Method()
 	statusCode := c.Response().StatusCode()
@@ -85,6 +143,17 @@ func (i *Inertia) redirectCheck(c fiber.Ctx, err error) error {
 	return err
 }
 
+// addInertiaResponseHeaders sets the Vary and Cache-Control headers of an Inertia response.
+func (i *Inertia) addInertiaResponseHeaders(c requestContext) {
+	addVaryHeader(c, HeaderInertia)
+	if i.precognitionVary {
+		addVaryHeader(c, HeaderPrecognition)
+	}
+	if i.shouldNoCacheResponse(c) {
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+	}
+}
+
 func getError(isAllowedErrorDetailsMessage bool, err error, fnGetError func(err error) *Error) *Error {
 	if err == nil {
 		// fallback error
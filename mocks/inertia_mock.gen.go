@@ -11,12 +11,13 @@ package goinertiamocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
+	goinertia "github.com/assurrussa/goinertia"
 	fiber "github.com/gofiber/fiber/v3"
 	gomock "go.uber.org/mock/gomock"
-
-	goinertia "github.com/assurrussa/goinertia"
 )
 
 // MockSessionStore is a mock of SessionStore interface.
@@ -115,6 +116,98 @@ func (mr *MockSessionStoreMockRecorder) Set(c, key, value any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockSessionStore)(nil).Set), c, key, value)
 }
 
+// MockHTTPSessionStore is a mock of HTTPSessionStore interface.
+type MockHTTPSessionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockHTTPSessionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockHTTPSessionStoreMockRecorder is the mock recorder for MockHTTPSessionStore.
+type MockHTTPSessionStoreMockRecorder struct {
+	mock *MockHTTPSessionStore
+}
+
+// NewMockHTTPSessionStore creates a new mock instance.
+func NewMockHTTPSessionStore(ctrl *gomock.Controller) *MockHTTPSessionStore {
+	mock := &MockHTTPSessionStore{ctrl: ctrl}
+	mock.recorder = &MockHTTPSessionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHTTPSessionStore) EXPECT() *MockHTTPSessionStoreMockRecorder {
+	return m.recorder
+}
+
+// Flash mocks base method.
+func (m *MockHTTPSessionStore) Flash(ctx context.Context, key string, value any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flash", ctx, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flash indicates an expected call of Flash.
+func (mr *MockHTTPSessionStoreMockRecorder) Flash(ctx, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flash", reflect.TypeOf((*MockHTTPSessionStore)(nil).Flash), ctx, key, value)
+}
+
+// GetFlash mocks base method.
+func (m *MockHTTPSessionStore) GetFlash(ctx context.Context, key string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlash", ctx, key)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlash indicates an expected call of GetFlash.
+func (mr *MockHTTPSessionStoreMockRecorder) GetFlash(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlash", reflect.TypeOf((*MockHTTPSessionStore)(nil).GetFlash), ctx, key)
+}
+
+// MockHTTPSessionGetter is a mock of HTTPSessionGetter interface.
+type MockHTTPSessionGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHTTPSessionGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockHTTPSessionGetterMockRecorder is the mock recorder for MockHTTPSessionGetter.
+type MockHTTPSessionGetterMockRecorder struct {
+	mock *MockHTTPSessionGetter
+}
+
+// NewMockHTTPSessionGetter creates a new mock instance.
+func NewMockHTTPSessionGetter(ctrl *gomock.Controller) *MockHTTPSessionGetter {
+	mock := &MockHTTPSessionGetter{ctrl: ctrl}
+	mock.recorder = &MockHTTPSessionGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHTTPSessionGetter) EXPECT() *MockHTTPSessionGetterMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockHTTPSessionGetter) Get(ctx context.Context, key string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHTTPSessionGetterMockRecorder) Get(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHTTPSessionGetter)(nil).Get), ctx, key)
+}
+
 // MockSessionAdapter is a mock of SessionAdapter interface.
 type MockSessionAdapter[T goinertia.FiberSessionStore] struct {
 	ctrl     *gomock.Controller
@@ -373,3 +466,356 @@ func (mr *MockSSRClientMockRecorder) Reset() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockSSRClient)(nil).Reset))
 }
+
+// MockSSRHealthClient is a mock of SSRHealthClient interface.
+type MockSSRHealthClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSRHealthClientMockRecorder
+	isgomock struct{}
+}
+
+// MockSSRHealthClientMockRecorder is the mock recorder for MockSSRHealthClient.
+type MockSSRHealthClientMockRecorder struct {
+	mock *MockSSRHealthClient
+}
+
+// NewMockSSRHealthClient creates a new mock instance.
+func NewMockSSRHealthClient(ctrl *gomock.Controller) *MockSSRHealthClient {
+	mock := &MockSSRHealthClient{ctrl: ctrl}
+	mock.recorder = &MockSSRHealthClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSRHealthClient) EXPECT() *MockSSRHealthClientMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSSRHealthClient) Get(ctx context.Context, url string, headers map[string]string) (int, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, url, headers)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSSRHealthClientMockRecorder) Get(ctx, url, headers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSSRHealthClient)(nil).Get), ctx, url, headers)
+}
+
+// MockSSRCache is a mock of SSRCache interface.
+type MockSSRCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSRCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockSSRCacheMockRecorder is the mock recorder for MockSSRCache.
+type MockSSRCacheMockRecorder struct {
+	mock *MockSSRCache
+}
+
+// NewMockSSRCache creates a new mock instance.
+func NewMockSSRCache(ctrl *gomock.Controller) *MockSSRCache {
+	mock := &MockSSRCache{ctrl: ctrl}
+	mock.recorder = &MockSSRCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSRCache) EXPECT() *MockSSRCacheMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSSRCache) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSSRCacheMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSSRCache)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockSSRCache) Get(ctx context.Context, key string) (*goinertia.SsrDTO, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*goinertia.SsrDTO)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSSRCacheMockRecorder) Get(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSSRCache)(nil).Get), ctx, key)
+}
+
+// Purge mocks base method.
+func (m *MockSSRCache) Purge(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockSSRCacheMockRecorder) Purge(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockSSRCache)(nil).Purge), ctx)
+}
+
+// Set mocks base method.
+func (m *MockSSRCache) Set(ctx context.Context, key string, value *goinertia.SsrDTO, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, key, value, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockSSRCacheMockRecorder) Set(ctx, key, value, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockSSRCache)(nil).Set), ctx, key, value, ttl)
+}
+
+// MockSSRCachePrefixDeleter is a mock of SSRCachePrefixDeleter interface.
+type MockSSRCachePrefixDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSRCachePrefixDeleterMockRecorder
+	isgomock struct{}
+}
+
+// MockSSRCachePrefixDeleterMockRecorder is the mock recorder for MockSSRCachePrefixDeleter.
+type MockSSRCachePrefixDeleterMockRecorder struct {
+	mock *MockSSRCachePrefixDeleter
+}
+
+// NewMockSSRCachePrefixDeleter creates a new mock instance.
+func NewMockSSRCachePrefixDeleter(ctrl *gomock.Controller) *MockSSRCachePrefixDeleter {
+	mock := &MockSSRCachePrefixDeleter{ctrl: ctrl}
+	mock.recorder = &MockSSRCachePrefixDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSRCachePrefixDeleter) EXPECT() *MockSSRCachePrefixDeleterMockRecorder {
+	return m.recorder
+}
+
+// DeletePrefix mocks base method.
+func (m *MockSSRCachePrefixDeleter) DeletePrefix(ctx context.Context, prefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrefix", ctx, prefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrefix indicates an expected call of DeletePrefix.
+func (mr *MockSSRCachePrefixDeleterMockRecorder) DeletePrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrefix", reflect.TypeOf((*MockSSRCachePrefixDeleter)(nil).DeletePrefix), ctx, prefix)
+}
+
+// MockSSRCacheStatsProvider is a mock of SSRCacheStatsProvider interface.
+type MockSSRCacheStatsProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSRCacheStatsProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockSSRCacheStatsProviderMockRecorder is the mock recorder for MockSSRCacheStatsProvider.
+type MockSSRCacheStatsProviderMockRecorder struct {
+	mock *MockSSRCacheStatsProvider
+}
+
+// NewMockSSRCacheStatsProvider creates a new mock instance.
+func NewMockSSRCacheStatsProvider(ctrl *gomock.Controller) *MockSSRCacheStatsProvider {
+	mock := &MockSSRCacheStatsProvider{ctrl: ctrl}
+	mock.recorder = &MockSSRCacheStatsProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSRCacheStatsProvider) EXPECT() *MockSSRCacheStatsProviderMockRecorder {
+	return m.recorder
+}
+
+// Stats mocks base method.
+func (m *MockSSRCacheStatsProvider) Stats() goinertia.SSRCacheStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(goinertia.SSRCacheStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockSSRCacheStatsProviderMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockSSRCacheStatsProvider)(nil).Stats))
+}
+
+// MockFileStorage is a mock of FileStorage interface.
+type MockFileStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockFileStorageMockRecorder is the mock recorder for MockFileStorage.
+type MockFileStorageMockRecorder struct {
+	mock *MockFileStorage
+}
+
+// NewMockFileStorage creates a new mock instance.
+func NewMockFileStorage(ctrl *gomock.Controller) *MockFileStorage {
+	mock := &MockFileStorage{ctrl: ctrl}
+	mock.recorder = &MockFileStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileStorage) EXPECT() *MockFileStorageMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockFileStorage) Save(ctx context.Context, name string, src io.Reader) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, name, src)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockFileStorageMockRecorder) Save(ctx, name, src any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFileStorage)(nil).Save), ctx, name, src)
+}
+
+// MockFileRemover is a mock of FileRemover interface.
+type MockFileRemover struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileRemoverMockRecorder
+	isgomock struct{}
+}
+
+// MockFileRemoverMockRecorder is the mock recorder for MockFileRemover.
+type MockFileRemoverMockRecorder struct {
+	mock *MockFileRemover
+}
+
+// NewMockFileRemover creates a new mock instance.
+func NewMockFileRemover(ctrl *gomock.Controller) *MockFileRemover {
+	mock := &MockFileRemover{ctrl: ctrl}
+	mock.recorder = &MockFileRemoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileRemover) EXPECT() *MockFileRemoverMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFileRemover) Delete(ctx context.Context, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFileRemoverMockRecorder) Delete(ctx, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFileRemover)(nil).Delete), ctx, path)
+}
+
+// MockTranslator is a mock of Translator interface.
+type MockTranslator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslatorMockRecorder
+	isgomock struct{}
+}
+
+// MockTranslatorMockRecorder is the mock recorder for MockTranslator.
+type MockTranslatorMockRecorder struct {
+	mock *MockTranslator
+}
+
+// NewMockTranslator creates a new mock instance.
+func NewMockTranslator(ctrl *gomock.Controller) *MockTranslator {
+	mock := &MockTranslator{ctrl: ctrl}
+	mock.recorder = &MockTranslatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslator) EXPECT() *MockTranslatorMockRecorder {
+	return m.recorder
+}
+
+// Translate mocks base method.
+func (m *MockTranslator) Translate(locale, key string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Translate", locale, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Translate indicates an expected call of Translate.
+func (mr *MockTranslatorMockRecorder) Translate(locale, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Translate", reflect.TypeOf((*MockTranslator)(nil).Translate), locale, key)
+}
+
+// MockValidator is a mock of Validator interface.
+type MockValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorMockRecorder
+	isgomock struct{}
+}
+
+// MockValidatorMockRecorder is the mock recorder for MockValidator.
+type MockValidatorMockRecorder struct {
+	mock *MockValidator
+}
+
+// NewMockValidator creates a new mock instance.
+func NewMockValidator(ctrl *gomock.Controller) *MockValidator {
+	mock := &MockValidator{ctrl: ctrl}
+	mock.recorder = &MockValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockValidator) Validate(ctx context.Context, value any) (goinertia.ValidationErrors, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", ctx, value)
+	ret0, _ := ret[0].(goinertia.ValidationErrors)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockValidatorMockRecorder) Validate(ctx, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidator)(nil).Validate), ctx, value)
+}
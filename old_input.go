@@ -0,0 +1,121 @@
+package goinertia
+
+import (
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v3"
+)
+
+// DefaultOldInputExcept lists the fields never flashed as old input by default.
+var DefaultOldInputExcept = []string{"password", "token", "secret", "card", "cvc", "cvv"}
+
+// OldInputConfig configures the old input captured by MiddlewareErrorListener (see WithOldInput).
+type OldInputConfig struct {
+	// Except drops every field whose name contains one of the entries, case-insensitively, at any depth.
+	// Default: DefaultOldInputExcept. Append to it to keep the defaults.
+	Except []string
+}
+
+// captureOldInput flashes the request body as the old prop when a form submission fails validation.
+// File parts are skipped, and a handler that already called WithFlashOld keeps its data.
+func (i *Inertia) captureOldInput(c fiber.Ctx, errReturn *Error) {
+	if i.oldInput == nil || c.Method() == fiber.MethodGet {
+		return
+	}
+	if len(errReturn.ValidationErrors()) == 0 && errReturn.Code != fiber.StatusUnprocessableEntity {
+		return
+	}
+	if old, ok := i.getContextKeyProps(i.fiberCtx(c))[ContextPropsOld].(map[string]any); ok && len(old) > 0 {
+		return
+	}
+
+	except := i.oldInput.Except
+	if except == nil {
+		except = DefaultOldInputExcept
+	}
+	if input := filterOldInput(requestInput(c), except); len(input) > 0 {
+		i.WithFlashOld(c, input)
+	}
+}
+
+// requestInput parses a JSON, form or multipart body. Fields with several values become string slices.
+func requestInput(c fiber.Ctx) map[string]any {
+	contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+	switch {
+	case strings.HasPrefix(contentType, fiber.MIMEApplicationJSON):
+		var input map[string]any
+		if err := json.Unmarshal(c.Body(), &input); err != nil {
+			return nil
+		}
+		return input
+	case strings.HasPrefix(contentType, fiber.MIMEMultipartForm):
+		form, err := c.MultipartForm()
+		if err != nil {
+			return nil
+		}
+		return formInput(form.Value)
+	case strings.HasPrefix(contentType, fiber.MIMEApplicationForm):
+		values := make(map[string][]string)
+		for key, value := range c.Request().PostArgs().All() {
+			values[string(key)] = append(values[string(key)], string(value))
+		}
+		return formInput(values)
+	default:
+		return nil
+	}
+}
+
+func formInput(values map[string][]string) map[string]any {
+	input := make(map[string]any, len(values))
+	for key, vals := range values {
+		if len(vals) == 1 {
+			input[key] = vals[0]
+		} else {
+			input[key] = vals
+		}
+	}
+	return input
+}
+
+// filterOldInput returns a copy of input without the denied fields and null values, which the session
+// encoder cannot store.
+func filterOldInput(input map[string]any, except []string) map[string]any {
+	res := make(map[string]any, len(input))
+	for key, value := range input {
+		if value == nil || oldInputDenied(key, except) {
+			continue
+		}
+		res[key] = filterOldInputValue(value, except)
+	}
+	return res
+}
+
+func filterOldInputValue(value any, except []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return filterOldInput(v, except)
+	case []any:
+		res := make([]any, len(v))
+		for n, item := range v {
+			// Null items become empty strings, so the indexes still match the validation keys.
+			res[n] = ""
+			if item != nil {
+				res[n] = filterOldInputValue(item, except)
+			}
+		}
+		return res
+	default:
+		return value
+	}
+}
+
+func oldInputDenied(field string, except []string) bool {
+	field = strings.ToLower(field)
+	for _, denied := range except {
+		if denied != "" && strings.Contains(field, strings.ToLower(denied)) {
+			return true
+		}
+	}
+	return false
+}
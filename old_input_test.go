@@ -0,0 +1,106 @@
+package goinertia_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+)
+
+func TestInertia_OldInput(t *testing.T) {
+	store := newTestStore()
+	adapter := goinertia.NewFiberSessionAdapter[*session.Session](store)
+	ta := inertiat.NewTestAppWithErrorHandler(t,
+		goinertia.WithSessionStore(adapter),
+		goinertia.WithOldInput(goinertia.OldInputConfig{
+			Except: append(goinertia.DefaultOldInputExcept, "ssn"),
+		}),
+	)
+
+	fail := func(fiber.Ctx) error {
+		return goinertia.NewValidationError(fiber.StatusUnprocessableEntity, "Invalid", goinertia.ValidationErrors{
+			"email": {"Invalid email"},
+		})
+	}
+	render := func(c fiber.Ctx) error { return ta.Inrt.Render(c, "Form", nil) }
+	oldAfter := func(t *testing.T, resp *http.Response, path string) any {
+		t.Helper()
+		require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+		//nolint:bodyclose // tests
+		_, body := ta.DoInertiaGet(render, map[string]string{"path": path}, resp.Cookies()...)
+		return inertiat.DecodePage(t, body).Props["old"]
+	}
+
+	//nolint:bodyclose // tests
+	resp, _ := ta.DoInertiaPostBody(fail, strings.NewReader(
+		`{"email":"bad","password":"secret1","user_ssn":"123","note":null,`+
+			`"items":[{"name":"a","cardNumber":"4242"},null]}`,
+	), map[string]string{"path": "/json", fiber.HeaderContentType: fiber.MIMEApplicationJSON})
+	assert.Equal(t, map[string]any{
+		"email": "bad",
+		"items": []any{map[string]any{"name": "a"}, ""},
+	}, oldAfter(t, resp, "/json-page"))
+
+	// Multipart file parts are skipped; repeated fields keep every value.
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("name", "John"))
+	require.NoError(t, writer.WriteField("tags", "a"))
+	require.NoError(t, writer.WriteField("tags", "b"))
+	require.NoError(t, writer.WriteField("api_token", "t"))
+	part, err := writer.CreateFormFile("avatar", "avatar.png")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("png"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	//nolint:bodyclose // tests
+	resp, _ = ta.DoInertiaPostBody(fail, body, map[string]string{
+		"path":                  "/multipart",
+		fiber.HeaderContentType: writer.FormDataContentType(),
+	})
+	assert.Equal(t, map[string]any{
+		"name": "John",
+		"tags": []any{"a", "b"},
+	}, oldAfter(t, resp, "/multipart-page"))
+
+	// Old input set by the handler wins.
+	//nolint:bodyclose // tests
+	resp, _ = ta.DoInertiaPostBody(func(c fiber.Ctx) error {
+		ta.Inrt.WithFlashOld(c, map[string]any{"email": "kept"})
+		return fail(c)
+	}, strings.NewReader("email=bad"), map[string]string{
+		"path":                  "/form",
+		fiber.HeaderContentType: fiber.MIMEApplicationForm,
+	})
+	assert.Equal(t, map[string]any{"email": "kept"}, oldAfter(t, resp, "/form-page"))
+}
+
+func TestInertia_OldInput_Disabled(t *testing.T) {
+	store := newTestStore()
+	adapter := goinertia.NewFiberSessionAdapter[*session.Session](store)
+	ta := inertiat.NewTestAppWithErrorHandler(t, goinertia.WithSessionStore(adapter))
+
+	//nolint:bodyclose // tests
+	resp, _ := ta.DoInertiaPostBody(func(fiber.Ctx) error {
+		return goinertia.NewValidationError(fiber.StatusUnprocessableEntity, "Invalid", goinertia.ValidationErrors{
+			"email": {"Invalid email"},
+		})
+	}, strings.NewReader("email=bad"), map[string]string{fiber.HeaderContentType: fiber.MIMEApplicationForm})
+	require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+
+	//nolint:bodyclose // tests
+	_, body := ta.DoInertiaGet(func(c fiber.Ctx) error {
+		return ta.Inrt.Render(c, "Form", nil)
+	}, map[string]string{"path": "/page"}, resp.Cookies()...)
+	assert.NotContains(t, inertiat.DecodePage(t, body).Props, "old")
+}
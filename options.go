@@ -17,7 +17,7 @@ var (
 		return nil
 	}
 	DefaultCustomErrorDetails = func(appErr *Error, isCanDetails bool) string {
-		details := "Something went wrong. Try again later"
+		details := MessagesEN[MessageErrorDefault]
 		switch {
 		case isCanDetails:
 			details = appErr.Error()
@@ -30,19 +30,19 @@ var (
 			code := appErr.Code
 			switch {
 			case code == http.StatusBadRequest:
-				details = "Bad request"
+				details = MessagesEN[MessageErrorBadRequest]
 			case code == fiber.StatusNotFound:
-				details = "Page not found"
+				details = MessagesEN[MessageErrorNotFound]
 			case code == fiber.StatusForbidden:
-				details = "Permission denied"
+				details = MessagesEN[MessageErrorForbidden]
 			case code == fiber.StatusUnauthorized:
-				details = "Unauthorized"
+				details = MessagesEN[MessageErrorUnauthorized]
 			case code == 419:
-				details = "The page expired, please try again"
+				details = MessagesEN[MessageErrorPageExpired]
 			case code == fiber.StatusTooManyRequests:
-				details = "Too many request"
+				details = MessagesEN[MessageErrorTooManyRequests]
 			case code >= http.StatusInternalServerError:
-				details = "Something went wrong. Try again later"
+				details = MessagesEN[MessageErrorDefault]
 			}
 		}
 
@@ -82,18 +82,49 @@ func WithRootErrorTemplate(rootErrorTemplate string) Option {
 	}
 }
 
+// WithViteManifest sets the path of the Vite build manifest inside the public FS.
+// Default: "dist/.vite/manifest.json".
+func WithViteManifest(path string) Option {
+	return func(i *Inertia) {
+		i.viteManifestPath = path
+	}
+}
+
 func WithAssetVersion(assetVersion string) Option {
 	return func(i *Inertia) {
 		i.assetVersion = assetVersion
 	}
 }
 
+// WithAssetVersionFromManifest derives the asset version from a hash of the Vite manifest in the public FS.
+// In dev mode the hash is recomputed when a file changes size or modtime, so a rebuild triggers a 409 reload.
+func WithAssetVersionFromManifest() Option {
+	return func(i *Inertia) {
+		i.assetVersionSource = &assetVersionSource{}
+	}
+}
+
+// WithAssetVersionFromFS derives the asset version from a hash of the given files or directories in fsys.
+// A nil fsys uses the public FS. In dev mode the hash is recomputed when a file changes size or modtime.
+func WithAssetVersionFromFS(fsys fs.FS, paths ...string) Option {
+	return func(i *Inertia) {
+		i.assetVersionSource = &assetVersionSource{fsys: fsys, paths: paths}
+	}
+}
+
 func WithSessionStore(sessionStore SessionStore) Option {
 	return func(i *Inertia) {
 		i.sessionStore = sessionStore
 	}
 }
 
+// WithHTTPSessionStore sets the session store used by the net/http adapter for flash data.
+func WithHTTPSessionStore(sessionStore HTTPSessionStore) Option {
+	return func(i *Inertia) {
+		i.httpSessionStore = sessionStore
+	}
+}
+
 func WithLogger(logger Logger) Option {
 	return func(i *Inertia) {
 		i.logger = logger
@@ -160,6 +191,22 @@ func WithCSRFTokenCheckProvider(provider CSRFTokenCheckProvider) Option {
 	}
 }
 
+// WithHTTPCSRFTokenProvider registers the resolver of the CSRF token injected into pages rendered by HTTPAdapter.
+func WithHTTPCSRFTokenProvider(provider HTTPCSRFTokenProvider) Option {
+	return func(i *Inertia) {
+		i.httpCSRFTokenProvider = provider
+	}
+}
+
+// WithHTTPCSRFTokenCheckProvider registers the check HTTPAdapter.Middleware runs on POST, PUT, PATCH and DELETE
+// requests when WithHTTPCSRFTokenProvider is set too. A failed check is answered with 419, or with the Code of
+// a returned *Error.
+func WithHTTPCSRFTokenCheckProvider(provider HTTPCSRFTokenCheckProvider) Option {
+	return func(i *Inertia) {
+		i.httpCSRFCheckProvider = provider
+	}
+}
+
 // WithCSRFPropName overrides the prop key used when injecting CSRF token.
 func WithCSRFPropName(prop string) Option {
 	return func(i *Inertia) {
@@ -175,6 +222,53 @@ func WithCSRFPropName(prop string) Option {
 	}
 }
 
+// WithErrorPage renders errors of Inertia GET requests as a page component instead of redirecting back.
+func WithErrorPage(cfg ErrorPageConfig) Option {
+	return func(i *Inertia) {
+		i.errorPage = cfg
+	}
+}
+
+// WithOldInput makes MiddlewareErrorListener flash the submitted fields as the old prop when a request fails
+// validation, so forms can be refilled after the redirect back. Denied fields and uploaded files are left out.
+func WithOldInput(cfg OldInputConfig) Option {
+	return func(i *Inertia) {
+		i.oldInput = &cfg
+	}
+}
+
+// WithFullValidationErrors keeps every message of a field in the errors prop, through redirects and error bags,
+// so that "errors" becomes a map of string arrays. By default only the first message of each field is kept.
+func WithFullValidationErrors() Option {
+	return func(i *Inertia) {
+		i.fullValidationErrors = true
+	}
+}
+
+// WithValidator sets the Validator used by BindAndValidate.
+func WithValidator(v Validator) Option {
+	return func(i *Inertia) {
+		i.validator = v
+	}
+}
+
+// WithTranslator localizes the default error details, the error page texts, Precognition error messages
+// and flashed error texts that are catalog keys or default English texts. Use NewDefaultTranslator for the
+// English and Russian catalogs.
+func WithTranslator(tr Translator) Option {
+	return func(i *Inertia) {
+		i.translator = tr
+	}
+}
+
+// WithLocaleResolver sets how the locale of a request is chosen, e.g. from a cookie or the user profile.
+// An empty result falls back to the Accept-Language header.
+func WithLocaleResolver(fn func(ctx context.Context, headers map[string][]string) string) Option {
+	return func(i *Inertia) {
+		i.localeResolver = fn
+	}
+}
+
 // WithSSRConfig enables SSR with the provided config.
 func WithSSRConfig(cfg SSRConfig) Option {
 	return func(i *Inertia) {
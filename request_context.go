@@ -0,0 +1,47 @@
+package goinertia
+
+import (
+	"context"
+	"io"
+)
+
+// requestContext is the transport-agnostic view of a single request.
+// Page building, partial reload parsing, prop resolution and flash handling work
+// against it, so every adapter (Fiber, net/http) only has to provide an implementation.
+type requestContext interface {
+	// RequestContext is passed to lazy props and to the logger.
+	RequestContext() context.Context
+	// UserContext is the parent context for outgoing calls (SSR).
+	UserContext() context.Context
+	// Get returns the request header value.
+	Get(key string) string
+	// Headers returns every request header.
+	Headers() map[string][]string
+	Method() string
+	OriginalURL() string
+	BaseURL() string
+	// Local returns per-request state stored under key.
+	Local(key contextKey) any
+	SetLocal(key contextKey, value any)
+	// Set sets the response header value.
+	Set(key, value string)
+	// ResponseHeader returns the response header value.
+	ResponseHeader(key string) string
+	StatusCode() int
+	SetStatus(code int)
+	Send(body []byte) error
+	// SendStream writes the body incrementally. With Fiber, stream runs after the handler has returned,
+	// so it must not use the request context.
+	SendStream(stream func(w streamWriter) error) error
+	HasSession() bool
+	Flash(key string, value any) error
+	GetFlash(key string) (any, error)
+	// PeekFlash returns the flashed value without consuming it.
+	PeekFlash(key string) (any, error)
+}
+
+// streamWriter is a buffered response writer whose Flush sends the buffered data to the client.
+type streamWriter interface {
+	io.Writer
+	Flush() error
+}
@@ -13,6 +13,8 @@ func init() {
 	gob.Register([]any{})
 	gob.Register(map[string]any{})
 	gob.Register(map[string]string{})
+	gob.Register(map[string][]string{})
+	gob.Register(map[string]map[string][]string{})
 }
 
 type FiberSessionAdapter[T FiberSessionStore] struct {
@@ -0,0 +1,359 @@
+package goinertia
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// DefaultLocale is the locale used when the request asks for none the translator knows.
+const DefaultLocale = "en"
+
+// Message keys of the texts goinertia produces itself. The error page template also uses
+// "error_page.title.<status>" and "error_page.description.<status>", with "default" for other statuses,
+// and "status.<code>" translates the standard HTTP status texts.
+const (
+	MessageErrorDefault         = "error.default"
+	MessageErrorBadRequest      = "error.bad_request"
+	MessageErrorUnauthorized    = "error.unauthorized"
+	MessageErrorForbidden       = "error.forbidden"
+	MessageErrorNotFound        = "error.not_found"
+	MessageErrorPageExpired     = "error.page_expired"
+	MessageErrorTooManyRequests = "error.too_many_requests"
+	MessageValidationFailed     = "validation.failed"
+
+	// Field messages a Validator may return as keys or as their English texts.
+	MessageValidationRequired = "validation.required"
+	MessageValidationInvalid  = "validation.invalid"
+	MessageValidationEmail    = "validation.email"
+	MessageValidationNumeric  = "validation.numeric"
+	MessageValidationTaken    = "validation.taken"
+	MessageValidationConfirm  = "validation.confirmed"
+
+	// Upload messages. Placeholders in braces are replaced: {size}, {types}, {type} and {count}.
+	MessageUploadTooLarge    = "upload.too_large"
+	MessageUploadExtension   = "upload.extension"
+	MessageUploadUnreadable  = "upload.unreadable"
+	MessageUploadContentType = "upload.content_type"
+	MessageUploadSuccess     = "upload.success"
+	MessageUploadSuccessMany = "upload.success_many"
+)
+
+// MessagesEN is the English catalog. Its texts are the defaults used without a translator.
+var MessagesEN = map[string]string{
+	MessageErrorDefault:         "Something went wrong. Try again later",
+	MessageErrorBadRequest:      "Bad request",
+	MessageErrorUnauthorized:    "Unauthorized",
+	MessageErrorForbidden:       "Permission denied",
+	MessageErrorNotFound:        "Page not found",
+	MessageErrorPageExpired:     "The page expired, please try again",
+	MessageErrorTooManyRequests: "Too many request",
+	MessageValidationFailed:     "The given data was invalid.",
+
+	MessageValidationRequired: "This field is required.",
+	MessageValidationInvalid:  "This field is invalid.",
+	MessageValidationEmail:    "This field must be a valid email address.",
+	MessageValidationNumeric:  "This field must be a number.",
+	MessageValidationTaken:    "This value has already been taken.",
+	MessageValidationConfirm:  "The confirmation does not match.",
+
+	MessageUploadTooLarge:    "The file may not be greater than {size}.",
+	MessageUploadExtension:   "The file must be of type: {types}.",
+	MessageUploadUnreadable:  "The file could not be read.",
+	MessageUploadContentType: "The file content type {type} is not allowed.",
+	MessageUploadSuccess:     "File uploaded successfully",
+	MessageUploadSuccessMany: "{count} files uploaded successfully",
+
+	"error_page.title.401":           "Authorization required",
+	"error_page.title.403":           "Access denied",
+	"error_page.title.404":           "Page not found",
+	"error_page.title.419":           "Page expired",
+	"error_page.title.429":           "Too many requests",
+	"error_page.title.500":           "Internal server error",
+	"error_page.title.default":       "An error occurred",
+	"error_page.description.401":     "Authorization is required to access this page.",
+	"error_page.description.403":     "You do not have permission to access this resource.",
+	"error_page.description.404":     "The requested page does not exist or has been moved.",
+	"error_page.description.419":     "The page has expired. Please try again.",
+	"error_page.description.429":     "Request limit exceeded. Please try again later.",
+	"error_page.description.500":     "A server error occurred. We are working on it.",
+	"error_page.description.default": "Something went wrong. Please refresh the page.",
+
+	"status.400": "Bad Request",
+	"status.401": "Unauthorized",
+	"status.403": "Forbidden",
+	"status.404": "Not Found",
+	"status.405": "Method Not Allowed",
+	"status.409": "Conflict",
+	"status.413": "Request Entity Too Large",
+	"status.422": "Unprocessable Entity",
+	"status.429": "Too Many Requests",
+	"status.500": "Internal Server Error",
+	"status.502": "Bad Gateway",
+	"status.503": "Service Unavailable",
+	"status.504": "Gateway Timeout",
+}
+
+// MessagesRU is the Russian catalog.
+var MessagesRU = map[string]string{
+	MessageErrorDefault:         "Что-то пошло не так. Попробуйте позже",
+	MessageErrorBadRequest:      "Некорректный запрос",
+	MessageErrorUnauthorized:    "Требуется авторизация",
+	MessageErrorForbidden:       "Доступ запрещён",
+	MessageErrorNotFound:        "Страница не найдена",
+	MessageErrorPageExpired:     "Срок действия страницы истёк, попробуйте ещё раз",
+	MessageErrorTooManyRequests: "Слишком много запросов",
+	MessageValidationFailed:     "Переданные данные некорректны.",
+
+	MessageValidationRequired: "Поле обязательно для заполнения.",
+	MessageValidationInvalid:  "Поле заполнено некорректно.",
+	MessageValidationEmail:    "Поле должно содержать корректный адрес электронной почты.",
+	MessageValidationNumeric:  "Поле должно быть числом.",
+	MessageValidationTaken:    "Такое значение уже занято.",
+	MessageValidationConfirm:  "Подтверждение не совпадает.",
+
+	MessageUploadTooLarge:    "Размер файла не может превышать {size}.",
+	MessageUploadExtension:   "Файл должен иметь один из типов: {types}.",
+	MessageUploadUnreadable:  "Не удалось прочитать файл.",
+	MessageUploadContentType: "Тип содержимого файла {type} не разрешён.",
+	MessageUploadSuccess:     "Файл успешно загружен",
+	MessageUploadSuccessMany: "Загружено файлов: {count}",
+
+	"error_page.title.401":           "Требуется авторизация",
+	"error_page.title.403":           "Доступ запрещён",
+	"error_page.title.404":           "Страница не найдена",
+	"error_page.title.419":           "Страница устарела",
+	"error_page.title.429":           "Слишком много запросов",
+	"error_page.title.500":           "Внутренняя ошибка сервера",
+	"error_page.title.default":       "Произошла ошибка",
+	"error_page.description.401":     "Для доступа к этой странице необходимо авторизоваться.",
+	"error_page.description.403":     "У вас нет прав для доступа к этому ресурсу.",
+	"error_page.description.404":     "Запрошенная страница не существует или была перемещена.",
+	"error_page.description.419":     "Срок действия страницы истёк. Попробуйте ещё раз.",
+	"error_page.description.429":     "Превышен лимит запросов. Попробуйте позже.",
+	"error_page.description.500":     "Произошла ошибка на сервере. Мы уже работаем над ней.",
+	"error_page.description.default": "Что-то пошло не так. Обновите страницу.",
+
+	"status.400": "Некорректный запрос",
+	"status.401": "Не авторизован",
+	"status.403": "Доступ запрещён",
+	"status.404": "Не найдено",
+	"status.405": "Метод не поддерживается",
+	"status.409": "Конфликт",
+	"status.413": "Слишком большой запрос",
+	"status.422": "Необрабатываемые данные",
+	"status.429": "Слишком много запросов",
+	"status.500": "Внутренняя ошибка сервера",
+	"status.502": "Ошибка шлюза",
+	"status.503": "Сервис недоступен",
+	"status.504": "Шлюз не отвечает",
+}
+
+// messageKeysEN maps the English texts back to their keys, so texts built without a request
+// (DefaultCustomErrorDetails, status messages) can still be translated. When keys share a text,
+// the "error." and "validation." keys win over the status and error page ones.
+var messageKeysEN = func() map[string]string {
+	rank := func(key string) int {
+		switch {
+		case strings.HasPrefix(key, "error."), strings.HasPrefix(key, "validation."):
+			return 0
+		case strings.HasPrefix(key, "status."):
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	keys := make(map[string]string, len(MessagesEN))
+	for key, text := range MessagesEN {
+		if prev, ok := keys[text]; !ok || rank(key) < rank(prev) || (rank(key) == rank(prev) && key < prev) {
+			keys[text] = key
+		}
+	}
+	return keys
+}()
+
+// CatalogTranslator is a Translator backed by in-memory catalogs keyed by locale.
+// A regional locale ("ru-RU") falls back to its language ("ru").
+type CatalogTranslator struct {
+	catalogs map[string]map[string]string
+}
+
+var _ Translator = (*CatalogTranslator)(nil)
+
+// NewCatalogTranslator creates a translator from catalogs keyed by locale.
+//
+// Example:
+//
+//	goinertia.NewCatalogTranslator(map[string]map[string]string{
+//		"en": goinertia.MessagesEN,
+//		"ru": goinertia.MessagesRU,
+//		"kk": kazakhMessages,
+//	})
+func NewCatalogTranslator(catalogs map[string]map[string]string) *CatalogTranslator {
+	normalized := make(map[string]map[string]string, len(catalogs))
+	for locale, catalog := range catalogs {
+		normalized[strings.ToLower(locale)] = catalog
+	}
+	return &CatalogTranslator{catalogs: normalized}
+}
+
+// NewDefaultTranslator returns a translator with the English and Russian catalogs.
+func NewDefaultTranslator() *CatalogTranslator {
+	return NewCatalogTranslator(map[string]map[string]string{"en": MessagesEN, "ru": MessagesRU})
+}
+
+func (t *CatalogTranslator) Translate(locale, key string) (string, bool) {
+	locale = strings.ToLower(locale)
+	if text, ok := t.catalogs[locale][key]; ok {
+		return text, true
+	}
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		if text, ok := t.catalogs[lang][key]; ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// Locale returns the locale of the request: the one of WithLocaleResolver, or else the first
+// Accept-Language entry the translator knows, or DefaultLocale.
+func (i *Inertia) Locale(c fiber.Ctx) string {
+	return i.locale(i.fiberCtx(c))
+}
+
+// Translate returns the text of key in the locale of the request. Without a translator, or when the key is
+// unknown, it returns the English text, or the key itself.
+//
+// Example:
+//
+//	return goinertia.NewValidationError(422, inrt.Translate(c, goinertia.MessageValidationFailed), errs)
+func (i *Inertia) Translate(c fiber.Ctx, key string) string {
+	return i.message(i.fiberCtx(c), key)
+}
+
+func (i *Inertia) locale(c requestContext) string {
+	if i.localeResolver != nil {
+		if locale := i.localeResolver(c.RequestContext(), c.Headers()); locale != "" {
+			return locale
+		}
+	}
+	if i.translator == nil {
+		return DefaultLocale
+	}
+	for _, locale := range parseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage)) {
+		if _, ok := i.translator.Translate(locale, MessageErrorDefault); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// message translates a catalog key, falling back to the English catalog and then to the key.
+func (i *Inertia) message(c requestContext, key string) string {
+	if i.translator != nil {
+		if text, ok := i.translator.Translate(i.locale(c), key); ok {
+			return text
+		}
+	}
+	if text, ok := MessagesEN[key]; ok {
+		return text
+	}
+	return key
+}
+
+// translateText translates a user-facing text that is either a catalog key or one of the English texts.
+// Other texts are returned unchanged, as is everything when no translator is configured.
+func (i *Inertia) translateText(c requestContext, text string) string {
+	if i.translator == nil || text == "" {
+		return text
+	}
+	key := text
+	if k, ok := messageKeysEN[text]; ok {
+		key = k
+	}
+	if translated, ok := i.translator.Translate(i.locale(c), key); ok {
+		return translated
+	}
+	return text
+}
+
+// formatMessage replaces the {name} placeholders of a text; params alternate names and values.
+func formatMessage(text string, params ...string) string {
+	if len(params) < 2 {
+		return text
+	}
+
+	pairs := make([]string, 0, len(params))
+	for n := 0; n+1 < len(params); n += 2 {
+		pairs = append(pairs, "{"+params[n]+"}", params[n+1])
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+func (i *Inertia) translateFlashErrors(c requestContext, errs []FlashError) []FlashError {
+	if i.translator == nil || len(errs) == 0 {
+		return errs
+	}
+
+	res := make([]FlashError, len(errs))
+	for n, flashErr := range errs {
+		res[n] = FlashError{Level: flashErr.Level, Message: i.translateText(c, flashErr.Message)}
+	}
+	return res
+}
+
+// errorPageMessage returns the error page text of kind ("title" or "description") for the status.
+func (i *Inertia) errorPageMessage(c requestContext, kind string, status int) string {
+	key := "error_page." + kind + "." + strconv.Itoa(status)
+	if _, ok := MessagesEN[key]; !ok {
+		key = "error_page." + kind + ".default"
+	}
+	return i.message(c, key)
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header, most preferred first.
+func parseAcceptLanguage(header string) []string {
+	type entry struct {
+		tag string
+		q   float64
+	}
+
+	var entries []entry
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			entries = append(entries, entry{tag: tag, q: q})
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	tags := make([]string, 0, len(entries))
+	for _, e := range entries {
+		tags = append(tags, e.tag)
+	}
+	return tags
+}
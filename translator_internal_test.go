@@ -0,0 +1,23 @@
+package goinertia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"ru-RU", "ru", "en"}, parseAcceptLanguage("en;q=0.5, ru-RU, *;q=0.1, ru;q=0.9, de;q=0"))
+	assert.Equal(t, []string{"kk", "ru"}, parseAcceptLanguage("kk, ru;q=bad, ru"))
+	assert.Empty(t, parseAcceptLanguage(""))
+}
+
+func TestMessageKeysEN(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, MessageErrorNotFound, messageKeysEN["Page not found"])
+	assert.Equal(t, MessageErrorUnauthorized, messageKeysEN["Unauthorized"])
+	assert.Equal(t, "status.500", messageKeysEN["Internal Server Error"])
+}
@@ -0,0 +1,124 @@
+package goinertia_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+)
+
+func TestInertia_Translator_ErrorDetails(t *testing.T) {
+	ta := inertiat.NewTestAppWithErrorHandler(t, goinertia.WithTranslator(goinertia.NewDefaultTranslator()))
+	notFound := func(fiber.Ctx) error { return goinertia.NewError(fiber.StatusNotFound, "missing") }
+
+	//nolint:bodyclose // tests
+	resp, body := ta.DoGet(notFound, map[string]string{
+		"path":                     "/ru",
+		fiber.HeaderAcceptLanguage: "de-DE, ru-RU;q=0.9, en;q=0.8",
+	})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, body, "Страница не найдена")
+
+	//nolint:bodyclose // tests
+	_, body = ta.DoGet(notFound, map[string]string{"path": "/en"})
+	assert.Contains(t, body, "Page not found")
+
+	//nolint:bodyclose // tests
+	resp, body = ta.DoGet(func(fiber.Ctx) error {
+		return fiber.ErrInternalServerError
+	}, map[string]string{
+		"path":                       "/precognition",
+		fiber.HeaderAcceptLanguage:   "ru",
+		goinertia.HeaderPrecognition: "true",
+	})
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	var payload struct {
+		Message string `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &payload))
+	assert.Equal(t, "Внутренняя ошибка сервера", payload.Message)
+}
+
+func TestInertia_Translator_FlashErrors(t *testing.T) {
+	store := newTestStore()
+	adapter := goinertia.NewFiberSessionAdapter[*session.Session](store)
+	ta := inertiat.NewTestAppWithErrorHandler(t,
+		goinertia.WithSessionStore(adapter),
+		goinertia.WithTranslator(goinertia.NewDefaultTranslator()),
+		goinertia.WithLocaleResolver(func(context.Context, map[string][]string) string { return "ru" }),
+	)
+
+	//nolint:bodyclose // tests
+	resp, _ := ta.DoInertiaPost(func(fiber.Ctx) error {
+		return goinertia.NewError(fiber.StatusBadRequest, "").
+			WithFlashErrors(goinertia.NewFlashError(goinertia.FlashLevelWarning, goinertia.MessageErrorPageExpired))
+	}, nil)
+	require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+
+	//nolint:bodyclose // tests
+	_, body := ta.DoInertiaGet(func(c fiber.Ctx) error {
+		return ta.Inrt.Render(c, "Form", nil)
+	}, map[string]string{"path": "/page"}, resp.Cookies()...)
+	flash, ok := inertiat.DecodePage(t, body).Props["flash"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, goinertia.MessagesRU[goinertia.MessageErrorPageExpired], flash["warning"])
+	assert.Equal(t, goinertia.MessagesRU[goinertia.MessageErrorBadRequest], flash["error"])
+}
+
+func TestInertia_Translate(t *testing.T) {
+	ta := inertiat.NewTestAppWithoutMiddleware(t, goinertia.WithTranslator(goinertia.NewDefaultTranslator()))
+
+	var locale, text, validationMessage string
+	//nolint:bodyclose // tests
+	ta.DoPostBody(func(c fiber.Ctx) error {
+		locale = ta.Inrt.Locale(c)
+		text = ta.Inrt.Translate(c, goinertia.MessageErrorNotFound)
+		return c.SendStatus(fiber.StatusNoContent)
+	}, nil, map[string]string{"path": "/translate", fiber.HeaderAcceptLanguage: "ru-RU"})
+	assert.Equal(t, "ru-RU", locale)
+	assert.Equal(t, "Страница не найдена", text)
+
+	withValidator := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithTranslator(goinertia.NewDefaultTranslator()),
+		goinertia.WithValidator(goinertia.ValidatorFunc(func(context.Context, any) (goinertia.ValidationErrors, error) {
+			return goinertia.ValidationErrors{"name": {"required"}}, nil
+		})),
+	)
+	//nolint:bodyclose // tests
+	withValidator.DoPostBody(func(c fiber.Ctx) error {
+		var req struct {
+			Name string `json:"name"`
+		}
+		validationMessage = withValidator.Inrt.BindAndValidate(c, &req).Error()
+		return c.SendStatus(fiber.StatusNoContent)
+	}, strings.NewReader(`{}`), map[string]string{
+		fiber.HeaderContentType:    fiber.MIMEApplicationJSON,
+		fiber.HeaderAcceptLanguage: "ru",
+	})
+	assert.Equal(t, "Переданные данные некорректны.", validationMessage)
+}
+
+func TestMessagesCatalogsComplete(t *testing.T) {
+	t.Parallel()
+
+	for key := range goinertia.MessagesEN {
+		assert.NotEmpty(t, goinertia.MessagesRU[key], key)
+	}
+	assert.Len(t, goinertia.MessagesRU, len(goinertia.MessagesEN))
+
+	tr := goinertia.NewDefaultTranslator()
+	text, ok := tr.Translate("RU-ru", goinertia.MessageErrorDefault)
+	assert.True(t, ok)
+	assert.Equal(t, goinertia.MessagesRU[goinertia.MessageErrorDefault], text)
+	_, ok = tr.Translate("de", goinertia.MessageErrorDefault)
+	assert.False(t, ok)
+}
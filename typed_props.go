@@ -0,0 +1,286 @@
+package goinertia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// ErrInvalidTypedProps is returned by RenderTyped for props that are not a struct or have an invalid inertia tag.
+var ErrInvalidTypedProps = errors.New("inertia: invalid typed props")
+
+// typedPropKind is the wrapper applied to a typed prop field.
+type typedPropKind int
+
+const (
+	typedPropPlain typedPropKind = iota
+	typedPropDefer
+	typedPropOptional
+	typedPropAlways
+)
+
+// typedPropField is the parsed form of a struct field and its `inertia` tag.
+type typedPropField struct {
+	index      []int
+	name       string
+	kind       typedPropKind
+	group      string
+	merge      bool
+	prepend    bool
+	deep       bool
+	once       bool
+	onceKey    string
+	isResolver bool
+}
+
+var typedPropFieldsCache sync.Map // map[reflect.Type][]typedPropField
+
+var (
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+)
+
+// RenderTyped renders a component from a props struct instead of map[string]any.
+//
+// Prop names come from the `inertia` tag, then the `json` tag, then the field name.
+// Tag options map onto the prop wrappers:
+//
+//	type UsersPage struct {
+//		Users   []User                                        `inertia:"users"`
+//		Stats   func(context.Context) (Stats, error)          `inertia:"stats,defer=sidebar"`
+//		Filters Filters                                       `inertia:"filters,optional"`
+//		Auth    Auth                                          `inertia:"auth,always"`
+//		Feed    []Post                                        `inertia:"feed,merge"`
+//		Plans   []Plan                                        `inertia:"plans,once"`
+//	}
+//
+// Available options: defer[=group], optional, always, merge[=prepend|deep], once[=key].
+// Fields of type func(context.Context) (T, error) are only called when the prop is part of the response,
+// so partial reloads skip evaluating excluded fields.
+func RenderTyped[P any](i *Inertia, c fiber.Ctx, component string, props P) error {
+	data, err := typedPropsMap(props)
+	if err != nil {
+		return err
+	}
+	return i.Render(c, component, data)
+}
+
+// typedPropsMap converts a props struct into the map consumed by Render.
+func typedPropsMap(props any) (map[string]any, error) {
+	value := reflect.ValueOf(props)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return map[string]any{}, nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is not a struct", ErrInvalidTypedProps, props)
+	}
+
+	fields, err := typedPropFields(value.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]any, len(fields))
+	for _, field := range fields {
+		fieldValue, ok := fieldByIndex(value, field.index)
+		if !ok {
+			continue
+		}
+		data[field.name] = field.wrap(fieldValue)
+	}
+
+	return data, nil
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex that skips fields behind nil embedded pointers.
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for pos, idx := range index {
+		if pos > 0 && value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		value = value.Field(idx)
+	}
+	return value, true
+}
+
+func (f typedPropField) wrap(fieldValue reflect.Value) any {
+	var value any
+	switch {
+	case f.isResolver && fieldValue.IsNil():
+		value = nil
+	case f.isResolver:
+		value = LazyProp{Key: f.name, Fn: resolverFunc(fieldValue)}
+	default:
+		value = fieldValue.Interface()
+	}
+
+	if f.merge {
+		value = MergeProp{Value: value, Prepend: f.prepend, Deep: f.deep}
+	}
+
+	switch f.kind {
+	case typedPropDefer:
+		value = Defer(value, f.group)
+	case typedPropOptional:
+		value = Optional(value)
+	case typedPropAlways:
+		value = Always(value)
+	case typedPropPlain:
+	}
+
+	if f.once {
+		value = OnceProp{Key: f.onceKey, Value: value}
+	}
+
+	return value
+}
+
+// resolverFunc adapts func(context.Context) (T, error) to a LazyProp function.
+func resolverFunc(fn reflect.Value) func(context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) {
+		out := fn.Call([]reflect.Value{reflect.ValueOf(&ctx).Elem()})
+		if errValue := out[1]; !errValue.IsNil() {
+			err, _ := errValue.Interface().(error)
+			return nil, err
+		}
+		return out[0].Interface(), nil
+	}
+}
+
+func typedPropFields(typ reflect.Type) ([]typedPropField, error) {
+	if cached, ok := typedPropFieldsCache.Load(typ); ok {
+		fields, _ := cached.([]typedPropField)
+		return fields, nil
+	}
+
+	fields, err := parseTypedPropFields(typ, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	typedPropFieldsCache.Store(typ, fields)
+	return fields, nil
+}
+
+func parseTypedPropFields(typ reflect.Type, parent []int) ([]typedPropField, error) {
+	var fields []typedPropField
+	for idx := range typ.NumField() {
+		field := typ.Field(idx)
+		index := append(append([]int(nil), parent...), idx)
+
+		tag, hasTag := field.Tag.Lookup("inertia")
+		if tag == "-" {
+			continue
+		}
+
+		if jsonName, _, _ := jsonFieldName(field); field.Anonymous && !hasTag && jsonName == "" {
+			if inner := derefType(field.Type); inner.Kind() == reflect.Struct {
+				nested, err := parseTypedPropFields(inner, index)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, nested...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		parsed, err := parseTypedPropTag(field, tag)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s.%s: %w", ErrInvalidTypedProps, typ.Name(), field.Name, err)
+		}
+		if parsed == nil {
+			continue
+		}
+		parsed.index = index
+		fields = append(fields, *parsed)
+	}
+	return fields, nil
+}
+
+// parseTypedPropTag parses `inertia:"name,defer=group,optional,always,merge,once"`.
+// It returns nil for fields excluded through the json tag.
+func parseTypedPropTag(field reflect.StructField, tag string) (*typedPropField, error) {
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		jsonName, _, skip := jsonFieldName(field)
+		if skip {
+			return nil, nil //nolint:nilnil // the field is excluded, not an error
+		}
+		name = jsonName
+	}
+	if name == "" {
+		name = field.Name
+	}
+
+	parsed := &typedPropField{name: name, isResolver: isResolverType(field.Type)}
+	for opt := range strings.SplitSeq(opts, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		if err := parsed.applyOption(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return parsed, nil
+}
+
+func (f *typedPropField) applyOption(key, value string) error {
+	setKind := func(kind typedPropKind) error {
+		if f.kind != typedPropPlain {
+			return errors.New("defer, optional and always are mutually exclusive")
+		}
+		f.kind = kind
+		return nil
+	}
+
+	switch key {
+	case "":
+		return nil
+	case "defer":
+		f.group = value
+		return setKind(typedPropDefer)
+	case "optional":
+		return setKind(typedPropOptional)
+	case "always":
+		return setKind(typedPropAlways)
+	case "merge":
+		f.merge = true
+		switch value {
+		case "":
+		case "prepend":
+			f.prepend = true
+		case "deep":
+			f.deep = true
+		default:
+			return fmt.Errorf("unknown merge mode %q", value)
+		}
+		return nil
+	case "once":
+		f.once = true
+		f.onceKey = value
+		return nil
+	default:
+		return fmt.Errorf("unknown option %q", key)
+	}
+}
+
+// isResolverType reports whether typ is func(context.Context) (T, error).
+func isResolverType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Func &&
+		typ.NumIn() == 1 && typ.In(0) == contextType &&
+		typ.NumOut() == 2 && typ.Out(1) == errorType &&
+		!typ.IsVariadic()
+}
@@ -0,0 +1,131 @@
+package goinertia_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+)
+
+type typedStats struct {
+	Total int `json:"total"`
+}
+
+type typedUsersPage struct {
+	Title   string                                    `inertia:"title"`
+	Users   []string                                  `json:"users"`
+	Stats   func(context.Context) (typedStats, error) `inertia:"stats,defer=sidebar"`
+	Filters map[string]string                         `inertia:"filters,optional"`
+	Auth    string                                    `inertia:"auth,always"`
+	Feed    []int                                     `inertia:"feed,merge=prepend"`
+	Plans   []string                                  `inertia:"plans,once=plans_v1"`
+	Hidden  string                                    `inertia:"-"`
+}
+
+func TestRenderTyped_Full(t *testing.T) {
+	t.Parallel()
+
+	var statsCalls atomic.Int32
+	ta := inertiat.NewTestAppWithoutMiddleware(t)
+	//nolint:bodyclose // tests
+	resp, body := ta.DoInertiaGet(func(c fiber.Ctx) error {
+		return goinertia.RenderTyped(ta.Inrt, c, "Users/Index", typedUsersPage{
+			Title: "Users",
+			Users: []string{"john"},
+			Stats: func(context.Context) (typedStats, error) {
+				statsCalls.Add(1)
+				return typedStats{Total: 1}, nil
+			},
+			Filters: map[string]string{"q": "jo"},
+			Auth:    "admin",
+			Feed:    []int{1, 2},
+			Plans:   []string{"pro"},
+			Hidden:  "secret",
+		})
+	}, nil)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	page := inertiat.DecodePage(t, body)
+	assert.Equal(t, "Users", page.Props["title"])
+	assert.Equal(t, []any{"john"}, page.Props["users"])
+	assert.Equal(t, "admin", page.Props["auth"])
+	assert.Equal(t, []any{float64(1), float64(2)}, page.Props["feed"])
+	assert.Equal(t, []any{"pro"}, page.Props["plans"])
+	assert.NotContains(t, page.Props, "stats")
+	assert.NotContains(t, page.Props, "filters")
+	assert.NotContains(t, page.Props, "Hidden")
+	assert.Equal(t, map[string][]string{"sidebar": {"stats"}}, page.DeferredProps)
+	assert.Equal(t, []string{"feed"}, page.PrependProps)
+	assert.Equal(t, map[string]goinertia.OncePropConfig{"plans_v1": {Prop: "plans"}}, page.OnceProps)
+	assert.Equal(t, int32(0), statsCalls.Load())
+}
+
+func TestRenderTyped_PartialReloadSkipsExcludedFields(t *testing.T) {
+	t.Parallel()
+
+	var statsCalls atomic.Int32
+	ta := inertiat.NewTestAppWithoutMiddleware(t)
+	handler := func(c fiber.Ctx) error {
+		return goinertia.RenderTyped(ta.Inrt, c, "Users/Index", &typedUsersPage{
+			Title: "Users",
+			Stats: func(context.Context) (typedStats, error) {
+				statsCalls.Add(1)
+				return typedStats{Total: 42}, nil
+			},
+			Auth: "admin",
+		})
+	}
+
+	//nolint:bodyclose // tests
+	_, body := ta.DoInertiaGet(handler, map[string]string{
+		goinertia.HeaderPartialComponent: "Users/Index",
+		goinertia.HeaderPartialOnly:      "stats",
+	})
+	page := inertiat.DecodePage(t, body)
+	assert.Equal(t, map[string]any{"total": float64(42)}, page.Props["stats"])
+	assert.Equal(t, "admin", page.Props["auth"])
+	assert.NotContains(t, page.Props, "title")
+	assert.Equal(t, int32(1), statsCalls.Load())
+
+	//nolint:bodyclose // tests
+	_, body = ta.DoInertiaGet(handler, map[string]string{
+		goinertia.HeaderPartialComponent: "Users/Index",
+		goinertia.HeaderPartialOnly:      "title",
+		"path":                           "/title",
+	})
+	page = inertiat.DecodePage(t, body)
+	assert.Equal(t, "Users", page.Props["title"])
+	assert.NotContains(t, page.Props, "stats")
+	assert.Equal(t, int32(1), statsCalls.Load())
+}
+
+func TestRenderTyped_InvalidProps(t *testing.T) {
+	t.Parallel()
+
+	type badTag struct {
+		Value string `inertia:"value,defer,optional"`
+	}
+
+	ta := inertiat.NewTestAppWithoutMiddleware(t)
+	var errs []error
+	//nolint:bodyclose // tests
+	ta.DoInertiaGet(func(c fiber.Ctx) error {
+		errs = append(errs,
+			goinertia.RenderTyped(ta.Inrt, c, "Home", map[string]any{"a": 1}),
+			goinertia.RenderTyped(ta.Inrt, c, "Home", badTag{}),
+		)
+		return c.SendStatus(http.StatusNoContent)
+	}, nil)
+
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		require.ErrorIs(t, err, goinertia.ErrInvalidTypedProps)
+	}
+}
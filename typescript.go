@@ -0,0 +1,535 @@
+package goinertia
+
+import (
+	"bufio"
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/goccy/go-json"
+)
+
+// typeScriptHeader is written at the top of every generated declaration file; %s is the ValidationErrors type.
+const typeScriptHeader = `// Code generated by goinertia-gen. DO NOT EDIT.
+
+export type FlashLevel = "success" | "info" | "warning" | "error";
+export type FlashMessages = Partial<Record<FlashLevel, string>>;
+export type ValidationErrors = %s;
+export type ErrorBags = Record<string, ValidationErrors>;
+export type OldInput = Record<string, unknown>;
+`
+
+// TypeScript types of the errors prop: the first message of each field, every message (WithFullValidationErrors),
+// or either one when the configuration is unknown.
+const (
+	tsValidationErrorsFirst = "Record<string, string>"
+	tsValidationErrorsFull  = "Record<string, string[]>"
+	tsValidationErrorsAny   = tsValidationErrorsFirst + " | " + tsValidationErrorsFull
+)
+
+var defaultTypeRegistry = newTypeRegistry()
+
+// typeRegistry collects the Go types used to generate TypeScript declarations.
+type typeRegistry struct {
+	mu     sync.RWMutex
+	pages  map[string]reflect.Type
+	shared []reflect.Type
+}
+
+func newTypeRegistry() *typeRegistry {
+	return &typeRegistry{pages: make(map[string]reflect.Type)}
+}
+
+func (r *typeRegistry) registerPage(name string, typ reflect.Type) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pages[name] = typ
+}
+
+func (r *typeRegistry) registerShared(typ reflect.Type) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shared = append(r.shared, typ)
+}
+
+// RegisterPage declares the props type of a page component for TypeScript generation.
+// T is usually a struct; its fields are named after their json tags.
+// Call it from an init function so that cmd/goinertia-gen can see it:
+//
+//	func init() {
+//		goinertia.RegisterPage[UsersIndexProps]("Users/Index")
+//	}
+func RegisterPage[T any](name string) {
+	defaultTypeRegistry.registerPage(name, reflect.TypeFor[T]())
+}
+
+// RegisterSharedProps declares the type of props shared by every page (see WithSharedProps).
+// T must be a struct; it may be registered several times with different types.
+func RegisterSharedProps[T any]() {
+	defaultTypeRegistry.registerShared(reflect.TypeFor[T]())
+}
+
+// GenerateTypeScript writes a .d.ts file for all pages and shared props registered
+// with RegisterPage and RegisterSharedProps. Without an Inertia instance, ValidationErrors
+// covers both the default and the WithFullValidationErrors form.
+func GenerateTypeScript(w io.Writer) error {
+	return writeTypeScript(w, defaultTypeRegistry, nil, "", tsValidationErrorsAny)
+}
+
+// GenerateTypeScript writes a .d.ts file like the package-level GenerateTypeScript
+// and also declares the props configured with WithSharedProps, typed from their values.
+// ValidationErrors follows WithFullValidationErrors.
+func (i *Inertia) GenerateTypeScript(w io.Writer) error {
+	csrfProp := ""
+	if i.hasCSRFTokenProvider() {
+		csrfProp = i.csrfPropName
+	}
+	validationErrors := tsValidationErrorsFirst
+	if i.fullValidationErrors {
+		validationErrors = tsValidationErrorsFull
+	}
+	return writeTypeScript(w, defaultTypeRegistry, i.sharedProps, csrfProp, validationErrors)
+}
+
+func writeTypeScript(
+	w io.Writer,
+	registry *typeRegistry,
+	sharedValues map[string]any,
+	csrfProp string,
+	validationErrors string,
+) error {
+	registry.mu.RLock()
+	pages := make(map[string]reflect.Type, len(registry.pages))
+	for name, typ := range registry.pages {
+		pages[name] = typ
+	}
+	shared := append([]reflect.Type(nil), registry.shared...)
+	registry.mu.RUnlock()
+
+	pageNames := make([]string, 0, len(pages))
+	for name := range pages {
+		pageNames = append(pageNames, name)
+	}
+	sort.Strings(pageNames)
+
+	gen := newTSGenerator()
+
+	// Page interface names are reserved first, so nested structs never reuse them, and a named page
+	// struct used as a field elsewhere refers to its page interface instead of being declared again.
+	pageInterfaces := make(map[string]string, len(pageNames))
+	for _, name := range pageNames {
+		ifaceName := uniqueName(componentInterfaceName(name), gen.used)
+		pageInterfaces[name] = ifaceName
+		if typ := derefType(pages[name]); typ.Kind() == reflect.Struct && typ.Name() != "" {
+			if _, ok := gen.names[typ]; !ok {
+				gen.names[typ] = ifaceName
+			}
+		}
+	}
+
+	sharedFields := []tsField{
+		{name: ContextPropsErrors, typ: "ValidationErrors | ErrorBags"},
+		{name: ContextPropsFlash, typ: "FlashMessages", optional: true},
+		{name: ContextPropsOld, typ: "OldInput", optional: true},
+	}
+	if csrfProp != "" {
+		sharedFields = append(sharedFields, tsField{name: csrfProp, typ: "string"})
+	}
+	for _, typ := range shared {
+		sharedFields = append(sharedFields, gen.structFields(derefType(typ))...)
+	}
+	sharedFields = append(sharedFields, gen.valueFields(sharedValues, csrfProp)...)
+
+	pageBodies := make(map[string]string, len(pageNames))
+	for _, name := range pageNames {
+		pageBodies[name] = gen.propsDecl(pageInterfaces[name], pages[name])
+	}
+
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(bw, typeScriptHeader, validationErrors)
+
+	for _, decl := range gen.sortedDecls() {
+		_, _ = fmt.Fprintf(bw, "\nexport interface %s %s\n", decl.name, decl.body)
+	}
+
+	_, _ = fmt.Fprintf(bw, "\nexport interface SharedProps %s\n", renderFields(dedupeFields(sharedFields)))
+
+	for _, name := range pageNames {
+		_, _ = fmt.Fprintf(bw, "\n%s\n", pageBodies[name])
+	}
+
+	_, _ = bw.WriteString("\nexport interface Pages {\n")
+	for _, name := range pageNames {
+		_, _ = fmt.Fprintf(bw, "  %s: %s;\n", strconv.Quote(name), pageInterfaces[name])
+	}
+	_, _ = bw.WriteString("}\n")
+	_, _ = bw.WriteString("\nexport type PageName = keyof Pages;\n")
+	_, _ = bw.WriteString("export type PageProps<N extends PageName> = Pages[N] & SharedProps;\n")
+
+	return bw.Flush()
+}
+
+type tsField struct {
+	name     string
+	typ      string
+	optional bool
+}
+
+type tsDecl struct {
+	name string
+	body string
+}
+
+// tsGenerator converts Go types into TypeScript, declaring named structs once.
+type tsGenerator struct {
+	names map[reflect.Type]string
+	used  map[string]struct{}
+	decls []tsDecl
+}
+
+func newTSGenerator() *tsGenerator {
+	return &tsGenerator{
+		names: make(map[reflect.Type]string),
+		used: map[string]struct{}{
+			"FlashLevel": {}, "FlashMessages": {}, "ValidationErrors": {}, "ErrorBags": {}, "OldInput": {},
+			"SharedProps": {}, "Pages": {}, "PageName": {}, "PageProps": {},
+		},
+	}
+}
+
+func (g *tsGenerator) sortedDecls() []tsDecl {
+	decls := append([]tsDecl(nil), g.decls...)
+	sort.Slice(decls, func(a, b int) bool { return decls[a].name < decls[b].name })
+	return decls
+}
+
+// propsDecl declares the props of a page. Non-struct props (e.g. maps) become a type alias.
+func (g *tsGenerator) propsDecl(name string, typ reflect.Type) string {
+	typ = derefType(typ)
+	if typ.Kind() == reflect.Struct {
+		return "export interface " + name + " " + renderFields(g.structFields(typ))
+	}
+	return "export type " + name + " = " + g.typeOf(typ) + ";"
+}
+
+// valueFields types props from concrete values, e.g. the map passed to WithSharedProps.
+func (g *tsGenerator) valueFields(values map[string]any, skip string) []tsField {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if key != skip {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	fields := make([]tsField, 0, len(keys))
+	for _, key := range keys {
+		typ, optional := g.valueType(values[key])
+		fields = append(fields, tsField{name: key, typ: typ, optional: optional})
+	}
+	return fields
+}
+
+// valueType unwraps prop wrappers; deferred and optional props may be absent from a response.
+func (g *tsGenerator) valueType(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "unknown", false
+	case DeferredProp:
+		typ, _ := g.valueType(v.Value)
+		return typ, true
+	case OptionalProp:
+		typ, _ := g.valueType(v.Value)
+		return typ, true
+	case AlwaysProp:
+		return g.valueType(v.Value)
+	case MergeProp:
+		return g.valueType(v.Value)
+	case ScrollProp:
+		return g.valueType(v.Value)
+	case OnceProp:
+		return g.valueType(v.Value)
+	case LazyProp:
+		return "unknown", false
+	default:
+		return g.typeOf(reflect.TypeOf(value)), false
+	}
+}
+
+func (g *tsGenerator) structFields(typ reflect.Type) []tsField {
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []tsField
+	for idx := range typ.NumField() {
+		field := typ.Field(idx)
+		tag, hasTag := field.Tag.Lookup("inertia")
+		if tag == "-" {
+			continue
+		}
+		name, omitEmpty, skip := jsonFieldName(field)
+		if skip && !hasTag {
+			continue
+		}
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" && !hasTag {
+			if inner := derefType(fieldType); inner.Kind() == reflect.Struct {
+				fields = append(fields, g.structFields(inner)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		tsType, optional := g.fieldType(fieldType)
+		optional = optional || omitEmpty
+		if hasTag {
+			// Typed props (see RenderTyped): the inertia tag decides the name and presence.
+			parsed, err := parseTypedPropTag(field, tag)
+			if err != nil || parsed == nil {
+				continue
+			}
+			name = parsed.name
+			optional = optional || parsed.kind == typedPropDefer || parsed.kind == typedPropOptional
+		}
+
+		fields = append(fields, tsField{name: name, typ: tsType, optional: optional})
+	}
+	return fields
+}
+
+// fieldType types a struct field. Wrapper values are untyped (any), so only their presence is known.
+func (g *tsGenerator) fieldType(typ reflect.Type) (string, bool) {
+	if isResolverType(typ) {
+		return g.typeOf(typ.Out(0)), false
+	}
+
+	switch typ {
+	case reflect.TypeFor[DeferredProp](), reflect.TypeFor[OptionalProp]():
+		return "unknown", true
+	case reflect.TypeFor[AlwaysProp](), reflect.TypeFor[MergeProp](), reflect.TypeFor[ScrollProp](),
+		reflect.TypeFor[OnceProp](), reflect.TypeFor[LazyProp]():
+		return "unknown", false
+	default:
+		return g.typeOf(typ), false
+	}
+}
+
+func (g *tsGenerator) typeOf(typ reflect.Type) string {
+	if typ == nil {
+		return "unknown"
+	}
+
+	switch {
+	case typ == reflect.TypeFor[time.Time]():
+		return "string"
+	case typ == reflect.TypeFor[json.RawMessage]():
+		return "unknown"
+	case typ.Implements(reflect.TypeFor[json.Marshaler]()):
+		return "unknown"
+	case typ.Implements(reflect.TypeFor[encoding.TextMarshaler]()):
+		return "string"
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Pointer:
+		return g.typeOf(typ.Elem()) + " | null"
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return arrayOf(g.typeOf(typ.Elem()))
+	case reflect.Array:
+		return arrayOf(g.typeOf(typ.Elem()))
+	case reflect.Map:
+		return "Record<string, " + g.typeOf(typ.Elem()) + ">"
+	case reflect.Struct:
+		return g.structType(typ)
+	default:
+		return "unknown"
+	}
+}
+
+func (g *tsGenerator) structType(typ reflect.Type) string {
+	if typ.Name() == "" {
+		return inlineFields(g.structFields(typ))
+	}
+	if name, ok := g.names[typ]; ok {
+		return name
+	}
+
+	name := uniqueName(typeName(typ), g.used)
+	g.names[typ] = name
+	// Reserve the declaration before rendering fields so recursive types resolve to the name.
+	idx := len(g.decls)
+	g.decls = append(g.decls, tsDecl{name: name})
+	g.decls[idx].body = renderFields(g.structFields(typ))
+
+	return name
+}
+
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return "", false, false
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	omitEmpty := false
+	for opt := range strings.SplitSeq(opts, ",") {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// dedupeFields keeps the last declaration of every field name.
+func dedupeFields(fields []tsField) []tsField {
+	index := make(map[string]int, len(fields))
+	result := make([]tsField, 0, len(fields))
+	for _, field := range fields {
+		if idx, ok := index[field.name]; ok {
+			result[idx] = field
+			continue
+		}
+		index[field.name] = len(result)
+		result = append(result, field)
+	}
+	return result
+}
+
+func renderFields(fields []tsField) string {
+	if len(fields) == 0 {
+		return "{}"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	for _, field := range fields {
+		sb.WriteString("  ")
+		sb.WriteString(propertyName(field.name))
+		if field.optional {
+			sb.WriteString("?")
+		}
+		sb.WriteString(": ")
+		sb.WriteString(field.typ)
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func inlineFields(fields []tsField) string {
+	if len(fields) == 0 {
+		return "Record<string, never>"
+	}
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		optional := ""
+		if field.optional {
+			optional = "?"
+		}
+		parts = append(parts, propertyName(field.name)+optional+": "+field.typ)
+	}
+	return "{ " + strings.Join(parts, "; ") + " }"
+}
+
+func arrayOf(elem string) string {
+	if strings.ContainsAny(elem, " |") {
+		return "(" + elem + ")[]"
+	}
+	return elem + "[]"
+}
+
+// propertyName quotes names that are not valid TypeScript identifiers.
+func propertyName(name string) string {
+	for idx, r := range name {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || (idx > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return strconv.Quote(name)
+	}
+	if name == "" {
+		return `""`
+	}
+	return name
+}
+
+// componentInterfaceName turns "Users/Index" into "UsersIndexProps".
+func componentInterfaceName(component string) string {
+	return pascalCase(component) + "Props"
+}
+
+// typeName turns a Go type name into a TypeScript identifier, dropping generic arguments.
+func typeName(typ reflect.Type) string {
+	name, _, _ := strings.Cut(typ.Name(), "[")
+	return pascalCase(name)
+}
+
+func pascalCase(value string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	if sb.Len() == 0 {
+		return "Page"
+	}
+	result := sb.String()
+	if unicode.IsDigit(rune(result[0])) {
+		result = "Page" + result
+	}
+	return result
+}
+
+func uniqueName(name string, used map[string]struct{}) string {
+	candidate := name
+	for n := 2; ; n++ {
+		if _, ok := used[candidate]; !ok {
+			used[candidate] = struct{}{}
+			return candidate
+		}
+		candidate = name + strconv.Itoa(n)
+	}
+}
@@ -0,0 +1,183 @@
+package goinertia
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tsTestUser struct {
+	ID        int           `json:"id"`
+	Name      string        `json:"name"`
+	Email     *string       `json:"email"`
+	CreatedAt time.Time     `json:"created_at"`
+	Friends   []*tsTestUser `json:"friends,omitempty"`
+	Internal  string        `json:"-"`
+	secret    string
+}
+
+type tsTestPage struct {
+	Users  []tsTestUser `json:"users"`
+	Stats  DeferredProp `json:"stats"`
+	Filter OptionalProp `json:"filter"`
+	Tags   map[string]int
+	Meta   struct {
+		Total int `json:"total"`
+	} `json:"meta"`
+}
+
+type tsTestShared struct {
+	AppName string `json:"appName"`
+}
+
+func TestWriteTypeScript_Pages(t *testing.T) {
+	t.Parallel()
+
+	registry := newTypeRegistry()
+	registry.registerPage("Users/Index", reflect.TypeFor[tsTestPage]())
+	registry.registerPage("dashboard", reflect.TypeFor[map[string]any]())
+	registry.registerShared(reflect.TypeFor[tsTestShared]())
+
+	var buf bytes.Buffer
+	require.NoError(t, writeTypeScript(&buf, registry, nil, "", tsValidationErrorsFirst))
+	out := buf.String()
+
+	assert.Contains(t, out, `export interface TsTestUser {
+  id: number;
+  name: string;
+  email: string | null;
+  created_at: string;
+  friends?: (TsTestUser | null)[];
+}`)
+	assert.Contains(t, out, `export interface UsersIndexProps {
+  users: TsTestUser[];
+  stats?: unknown;
+  filter?: unknown;
+  Tags: Record<string, number>;
+  meta: { total: number };
+}`)
+	assert.Contains(t, out, `export interface SharedProps {
+  errors: ValidationErrors | ErrorBags;
+  flash?: FlashMessages;
+  old?: OldInput;
+  appName: string;
+}`)
+	assert.Contains(t, out, "export type DashboardProps = Record<string, unknown>;")
+	assert.Contains(t, out, `export interface Pages {
+  "Users/Index": UsersIndexProps;
+  "dashboard": DashboardProps;
+}`)
+	assert.NotContains(t, out, "secret")
+	assert.NotContains(t, out, "Internal")
+}
+
+func TestWriteTypeScript_PageStructUsedAsField(t *testing.T) {
+	t.Parallel()
+
+	type DashboardProps struct {
+		Visits int `json:"visits"`
+	}
+	type HomeProps struct {
+		Dash DashboardProps `json:"dash"`
+	}
+
+	registry := newTypeRegistry()
+	registry.registerPage("Dashboard", reflect.TypeFor[DashboardProps]())
+	registry.registerPage("Home", reflect.TypeFor[HomeProps]())
+
+	var buf bytes.Buffer
+	require.NoError(t, writeTypeScript(&buf, registry, nil, "", tsValidationErrorsFirst))
+	out := buf.String()
+
+	assert.Equal(t, 1, strings.Count(out, "export interface DashboardProps "))
+	assert.Equal(t, 1, strings.Count(out, "export interface HomeProps "))
+	assert.Contains(t, out, `export interface HomeProps {
+  dash: DashboardProps;
+}`)
+}
+
+func TestInertia_GenerateTypeScriptSharedValues(t *testing.T) {
+	t.Parallel()
+
+	inrt := New("http://example.com",
+		WithCSRFTokenProvider(func(fiber.Ctx) (string, error) { return "token", nil }),
+		WithSharedProps(map[string]any{
+			"user":     tsTestUser{},
+			"features": Defer([]string{"a"}),
+			"counter":  Optional(1),
+			"lazy":     LazyProp{Key: "lazy", Fn: func(context.Context) (any, error) { return nil, nil }},
+		}),
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, inrt.GenerateTypeScript(&buf))
+
+	assert.Contains(t, buf.String(), `export interface SharedProps {
+  errors: ValidationErrors | ErrorBags;
+  flash?: FlashMessages;
+  old?: OldInput;
+  csrf_token: string;
+  counter?: number;
+  features?: string[];
+  lazy: unknown;
+  user: TsTestUser;
+}`)
+}
+
+func TestGenerateTypeScript_ValidationErrorsType(t *testing.T) {
+	t.Parallel()
+
+	generate := func(opts ...Option) string {
+		var buf bytes.Buffer
+		require.NoError(t, New("http://example.com", opts...).GenerateTypeScript(&buf))
+		return buf.String()
+	}
+
+	assert.Contains(t, generate(), "export type ValidationErrors = Record<string, string>;\n")
+	assert.Contains(t, generate(WithFullValidationErrors()), "export type ValidationErrors = Record<string, string[]>;\n")
+
+	var buf bytes.Buffer
+	require.NoError(t, GenerateTypeScript(&buf))
+	assert.Contains(t, buf.String(),
+		"export type ValidationErrors = Record<string, string> | Record<string, string[]>;\n")
+}
+
+func TestComponentInterfaceName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "UsersIndexProps", componentInterfaceName("Users/Index"))
+	assert.Equal(t, "AdminUserEditProps", componentInterfaceName("admin/user-edit"))
+	assert.Equal(t, "Page404Props", componentInterfaceName("404"))
+}
+
+func TestWriteTypeScript_TypedProps(t *testing.T) {
+	t.Parallel()
+
+	type typedPage struct {
+		Title   string                                      `inertia:"title"`
+		Stats   func(context.Context) (tsTestShared, error) `inertia:"stats,defer"`
+		Filters []string                                    `inertia:"filters,optional"`
+		Feed    []int                                       `inertia:"feed,merge"`
+		Hidden  string                                      `inertia:"-"`
+	}
+
+	registry := newTypeRegistry()
+	registry.registerPage("Typed", reflect.TypeFor[typedPage]())
+
+	var buf bytes.Buffer
+	require.NoError(t, writeTypeScript(&buf, registry, nil, "", tsValidationErrorsFirst))
+
+	assert.Contains(t, buf.String(), `export interface TypedProps {
+  title: string;
+  stats?: TsTestShared;
+  filters?: string[];
+  feed: number[];
+}`)
+}
@@ -0,0 +1,387 @@
+package goinertia
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	// DefaultUploadDir is the local directory used when FileUploadConfig has no Storage and no UploadDir.
+	DefaultUploadDir = "uploads"
+	// DefaultUploadMaxMemory is the multipart memory limit used by the net/http adapter.
+	DefaultUploadMaxMemory = 32 << 20
+	// sniffLen is the number of bytes http.DetectContentType considers.
+	sniffLen = 512
+)
+
+// ErrUploadStorage is returned when an uploaded file could not be persisted.
+var ErrUploadStorage = errors.New("inertia: could not store uploaded file")
+
+// FileUploadConfig configures ProcessFileUploads.
+type FileUploadConfig struct {
+	// MaxFileSize is the maximum size of a single file in bytes. Zero disables the check.
+	MaxFileSize int64
+	// AllowedExtensions lists accepted extensions (case-insensitive, e.g. ".jpg"). Empty allows any.
+	AllowedExtensions []string
+	// AllowedMIMETypes lists accepted sniffed content types ("image/png", "image/*"). Empty allows any type
+	// that does not contradict the file extension, e.g. HTML or a script uploaded as ".png".
+	AllowedMIMETypes []string
+	// UploadDir is the local directory used when Storage is nil. Default: "uploads".
+	UploadDir string
+	// Storage persists accepted files. Default: LocalFileStorage in UploadDir.
+	Storage FileStorage
+}
+
+// UploadedFile describes a stored file.
+type UploadedFile struct {
+	OriginalName string `json:"originalName"`
+	Name         string `json:"name"`
+	Path         string `json:"path"`
+	Size         int64  `json:"size"`
+	MIMEType     string `json:"mimeType"`
+}
+
+// FileUploadError describes why a single file was rejected.
+type FileUploadError struct {
+	Index    int
+	FileName string
+	Message  string
+}
+
+// FileUploadResult is the outcome of ProcessFileUploads.
+type FileUploadResult struct {
+	Field  string
+	Files  []UploadedFile
+	Errors []FileUploadError
+}
+
+// ValidationErrors converts per-file failures into errors keyed like "files.0".
+func (r *FileUploadResult) ValidationErrors() ValidationErrors {
+	if r == nil || len(r.Errors) == 0 {
+		return nil
+	}
+
+	errs := make(ValidationErrors, len(r.Errors))
+	for _, fileErr := range r.Errors {
+		key := r.Field + "." + strconv.Itoa(fileErr.Index)
+		errs[key] = append(errs[key], fileErr.Message)
+	}
+	return errs
+}
+
+// LocalFileStorage stores files in a local directory.
+type LocalFileStorage struct {
+	dir string
+}
+
+func NewLocalFileStorage(dir string) *LocalFileStorage {
+	return &LocalFileStorage{dir: dir}
+}
+
+func (s *LocalFileStorage) Save(_ context.Context, name string, src io.Reader) (string, error) {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create upload dir: %w", err)
+	}
+
+	path := filepath.Join(s.dir, filepath.Base(name))
+	// #nosec G304 - the file name is generated by ProcessFileUploads and stripped of directories
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(path)
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := dst.Close(); err != nil {
+		return "", fmt.Errorf("failed to close file: %w", err)
+	}
+
+	return path, nil
+}
+
+// Delete removes a file stored by Save.
+func (s *LocalFileStorage) Delete(_ context.Context, path string) error {
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// ProcessFileUploads validates and stores the multipart files sent under field.
+// Rejected files are reported in result.Errors; the error is only returned when the form
+// cannot be parsed or a file cannot be stored. In that case the files already stored are deleted
+// when the storage implements FileRemover, and are left in result.Files otherwise.
+func (i *Inertia) ProcessFileUploads(c fiber.Ctx, field string, config *FileUploadConfig) (*FileUploadResult, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse multipart form: %w", err)
+	}
+
+	return processFileUploads(c.Context(), form.File[field], field, config, i.uploadMessage(i.fiberCtx(c)))
+}
+
+// WithFileUploadErrors adds per-file failures as validation errors keyed like "files.0".
+func (i *Inertia) WithFileUploadErrors(c fiber.Ctx, result *FileUploadResult) {
+	i.WithValidationErrors(c, result.ValidationErrors())
+}
+
+// WithFileUploadSuccess adds a success flash message for the stored files.
+func (i *Inertia) WithFileUploadSuccess(c fiber.Ctx, files []UploadedFile) {
+	if len(files) == 0 {
+		return
+	}
+	i.WithFlashSuccess(c, uploadSuccessMessage(i.uploadMessage(i.fiberCtx(c)), len(files)))
+}
+
+// ProcessFileUploads validates and stores the multipart files sent under field.
+func (a *HTTPAdapter) ProcessFileUploads(r *http.Request, field string, config *FileUploadConfig) (*FileUploadResult, error) {
+	if err := r.ParseMultipartForm(DefaultUploadMaxMemory); err != nil {
+		return nil, fmt.Errorf("failed to parse multipart form: %w", err)
+	}
+
+	message := a.inertia.uploadMessage(a.context(nil, r))
+	return processFileUploads(r.Context(), r.MultipartForm.File[field], field, config, message)
+}
+
+// WithFileUploadErrors adds per-file failures as validation errors keyed like "files.0".
+func (a *HTTPAdapter) WithFileUploadErrors(r *http.Request, result *FileUploadResult) {
+	a.WithValidationErrors(r, result.ValidationErrors())
+}
+
+// WithFileUploadSuccess adds a success flash message for the stored files.
+func (a *HTTPAdapter) WithFileUploadSuccess(r *http.Request, files []UploadedFile) {
+	if len(files) == 0 {
+		return
+	}
+	a.WithFlashSuccess(r, uploadSuccessMessage(a.inertia.uploadMessage(a.context(nil, r)), len(files)))
+}
+
+// uploadMessageFunc returns the text of an upload message key with its {name} placeholders replaced.
+type uploadMessageFunc func(key string, params ...string) string
+
+// uploadMessage returns the upload messages in the locale of the request.
+func (i *Inertia) uploadMessage(c requestContext) uploadMessageFunc {
+	return func(key string, params ...string) string {
+		return formatMessage(i.message(c, key), params...)
+	}
+}
+
+func uploadSuccessMessage(message uploadMessageFunc, count int) string {
+	if count == 1 {
+		return message(MessageUploadSuccess)
+	}
+	return message(MessageUploadSuccessMany, "count", strconv.Itoa(count))
+}
+
+func processFileUploads(
+	ctx context.Context,
+	headers []*multipart.FileHeader,
+	field string,
+	config *FileUploadConfig,
+	message uploadMessageFunc,
+) (*FileUploadResult, error) {
+	if config == nil {
+		config = &FileUploadConfig{}
+	}
+
+	storage := config.Storage
+	if storage == nil {
+		dir := config.UploadDir
+		if dir == "" {
+			dir = DefaultUploadDir
+		}
+		storage = NewLocalFileStorage(dir)
+	}
+
+	result := &FileUploadResult{Field: field}
+	for index, header := range headers {
+		uploaded, rejection, err := processFileUpload(ctx, header, config, storage, message)
+		if err != nil {
+			result.Files = removeUploadedFiles(ctx, storage, result.Files)
+			return result, err
+		}
+		if rejection != "" {
+			result.Errors = append(result.Errors, FileUploadError{
+				Index:    index,
+				FileName: header.Filename,
+				Message:  rejection,
+			})
+			continue
+		}
+		result.Files = append(result.Files, *uploaded)
+	}
+
+	return result, nil
+}
+
+// removeUploadedFiles deletes the files of a failed batch when the storage can, and returns those left behind.
+func removeUploadedFiles(ctx context.Context, storage FileStorage, files []UploadedFile) []UploadedFile {
+	remover, ok := storage.(FileRemover)
+	if !ok {
+		return files
+	}
+
+	// The request may be cancelled already; the files must be deleted anyway.
+	ctx = context.WithoutCancel(ctx)
+	var left []UploadedFile
+	for _, file := range files {
+		if err := remover.Delete(ctx, file.Path); err != nil {
+			left = append(left, file)
+		}
+	}
+	return left
+}
+
+// processFileUpload returns either the stored file, a user-facing rejection message or a storage error.
+func processFileUpload(
+	ctx context.Context,
+	header *multipart.FileHeader,
+	config *FileUploadConfig,
+	storage FileStorage,
+	message uploadMessageFunc,
+) (*UploadedFile, string, error) {
+	if config.MaxFileSize > 0 && header.Size > config.MaxFileSize {
+		return nil, message(MessageUploadTooLarge, "size", formatFileSize(config.MaxFileSize)), nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	if !isAllowedExtension(ext, config.AllowedExtensions) {
+		return nil, message(MessageUploadExtension, "types", strings.Join(config.AllowedExtensions, ", ")), nil
+	}
+
+	src, err := header.Open()
+	if err != nil {
+		return nil, message(MessageUploadUnreadable), nil //nolint:nilerr // unreadable part is a per-file failure
+	}
+	defer func() { _ = src.Close() }()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, message(MessageUploadUnreadable), nil //nolint:nilerr // unreadable part is a per-file failure
+	}
+	head = head[:n]
+
+	mimeType := http.DetectContentType(head)
+	if contradictsExtension(ext, mimeType) || !isAllowedMIMEType(mimeType, config.AllowedMIMETypes) {
+		return nil, message(MessageUploadContentType, "type", mediaType(mimeType)), nil
+	}
+
+	name, err := randomFileName(ext)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrUploadStorage, err)
+	}
+
+	path, err := storage.Save(ctx, name, io.MultiReader(bytes.NewReader(head), src))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrUploadStorage, err)
+	}
+
+	return &UploadedFile{
+		OriginalName: filepath.Base(header.Filename),
+		Name:         name,
+		Path:         path,
+		Size:         header.Size,
+		MIMEType:     mimeType,
+	}, "", nil
+}
+
+func isAllowedExtension(ext string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, item := range allowed {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if !strings.HasPrefix(item, ".") {
+			item = "." + item
+		}
+		if item == ext {
+			return true
+		}
+	}
+	return false
+}
+
+func isAllowedMIMEType(mimeType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	detected := mediaType(mimeType)
+	for _, item := range allowed {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if prefix, ok := strings.CutSuffix(item, "/*"); ok {
+			if strings.HasPrefix(detected, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if item == detected {
+			return true
+		}
+	}
+	return false
+}
+
+// contradictsExtension reports whether the sniffed content type is not what the extension promises:
+// markup (HTML, XML) in any file whose extension is not a markup type, and text, such as a script,
+// in an image, audio, video or PDF file. Unknown extensions and unrecognized binary content pass.
+func contradictsExtension(ext, mimeType string) bool {
+	expected := mediaType(mime.TypeByExtension(ext))
+	detected := mediaType(mimeType)
+	if expected == "" || expected == detected {
+		return false
+	}
+
+	if detected == "text/html" || detected == "text/xml" {
+		return !strings.Contains(expected, "html") && !strings.Contains(expected, "xml")
+	}
+	binary := strings.HasPrefix(expected, "audio/") || strings.HasPrefix(expected, "video/") ||
+		expected == "application/pdf" || (strings.HasPrefix(expected, "image/") && expected != "image/svg+xml")
+	return binary && strings.HasPrefix(detected, "text/")
+}
+
+func mediaType(mimeType string) string {
+	parsed, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return strings.ToLower(mimeType)
+	}
+	return parsed
+}
+
+func formatFileSize(size int64) string {
+	const unit = 1024
+	switch {
+	case size >= unit*unit && size%(unit*unit) == 0:
+		return strconv.FormatInt(size/(unit*unit), 10) + " MB"
+	case size >= unit && size%unit == 0:
+		return strconv.FormatInt(size/unit, 10) + " KB"
+	default:
+		return strconv.FormatInt(size, 10) + " bytes"
+	}
+}
+
+func randomFileName(ext string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf) + ext, nil
+}
@@ -0,0 +1,295 @@
+package goinertia_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+	inertiamocks "github.com/assurrussa/goinertia/mocks"
+)
+
+func TestProcessFileUploads_Success(t *testing.T) {
+	t.Parallel()
+
+	uploadDir := t.TempDir()
+	ta := inertiat.NewTestApp(t)
+
+	var result *goinertia.FileUploadResult
+	body, writer := inertiat.CreateBody(t, "files", "doc-%d.txt", "first", "second")
+	resp, _ := ta.DoPostBody(func(c fiber.Ctx) error {
+		var err error
+		result, err = ta.Inrt.ProcessFileUploads(c, "files", &goinertia.FileUploadConfig{
+			MaxFileSize:       1024,
+			AllowedExtensions: []string{".txt"},
+			AllowedMIMETypes:  []string{"text/*"},
+			UploadDir:         uploadDir,
+		})
+		if err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusNoContent)
+	}, body, map[string]string{fiber.HeaderContentType: writer.FormDataContentType()})
+
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	require.NotNil(t, result)
+	assert.Empty(t, result.Errors)
+	require.Len(t, result.Files, 2)
+	assert.Equal(t, "doc-0.txt", result.Files[0].OriginalName)
+	assert.Equal(t, ".txt", filepath.Ext(result.Files[0].Name))
+	assert.Equal(t, filepath.Join(uploadDir, result.Files[0].Name), result.Files[0].Path)
+	assert.Equal(t, int64(len("first")), result.Files[0].Size)
+	assert.Contains(t, result.Files[0].MIMEType, "text/plain")
+	assert.ElementsMatch(t,
+		[][]byte{[]byte("first"), []byte("second")},
+		inertiat.ReadFileContents(t, filepath.Join(uploadDir, "*.txt")),
+	)
+}
+
+func TestProcessFileUploads_ValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	uploadDir := t.TempDir()
+	ta := inertiat.NewTestApp(t)
+
+	body, writer := inertiat.CreateBody(t, "files", "", "ok", "too large content")
+	resp, respBody := ta.DoInertiaPostBody(func(c fiber.Ctx) error {
+		result, err := ta.Inrt.ProcessFileUploads(c, "files", &goinertia.FileUploadConfig{
+			MaxFileSize:       10,
+			AllowedExtensions: []string{".txt"},
+			UploadDir:         uploadDir,
+		})
+		if err != nil {
+			return err
+		}
+		assert.Len(t, result.Files, 1)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "file-1.txt", result.Errors[0].FileName)
+
+		ta.Inrt.WithFileUploadErrors(c, result)
+		return ta.Inrt.Render(c, "Upload/Form", nil)
+	}, body, map[string]string{fiber.HeaderContentType: writer.FormDataContentType()})
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	page := inertiat.DecodePage(t, respBody)
+	assert.Equal(t, map[string]any{
+		"files.1": "The file may not be greater than 10 bytes.",
+	}, page.Props["errors"])
+	assert.Len(t, inertiat.ReadFileNames(t, filepath.Join(uploadDir, "*")), 1)
+}
+
+func TestProcessFileUploads_LocalizedMessages(t *testing.T) {
+	t.Parallel()
+
+	uploadDir := t.TempDir()
+	ta := inertiat.NewTestApp(t, goinertia.WithTranslator(goinertia.NewDefaultTranslator()))
+
+	body, writer := inertiat.CreateBody(t, "files", "", "ok", "too large content")
+	resp, respBody := ta.DoInertiaPostBody(func(c fiber.Ctx) error {
+		result, err := ta.Inrt.ProcessFileUploads(c, "files", &goinertia.FileUploadConfig{
+			MaxFileSize: 10,
+			UploadDir:   uploadDir,
+		})
+		if err != nil {
+			return err
+		}
+		ta.Inrt.WithFileUploadErrors(c, result)
+		ta.Inrt.WithFileUploadSuccess(c, result.Files)
+		return ta.Inrt.Render(c, "Upload/Form", nil)
+	}, body, map[string]string{
+		fiber.HeaderContentType:    writer.FormDataContentType(),
+		fiber.HeaderAcceptLanguage: "ru-RU,ru;q=0.9",
+	})
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	page := inertiat.DecodePage(t, respBody)
+	assert.Equal(t, map[string]any{"files.1": "Размер файла не может превышать 10 bytes."}, page.Props["errors"])
+	assert.Equal(t, map[string]any{"success": "Файл успешно загружен"}, page.Props["flash"])
+}
+
+func TestProcessFileUploads_RejectedTypes(t *testing.T) {
+	t.Parallel()
+
+	ta := inertiat.NewTestApp(t)
+
+	var result *goinertia.FileUploadResult
+	body, writer := inertiat.CreateBody(t, "files", "image-%d.png", "plain text, not a png")
+	body2, writer2 := inertiat.CreateBody(t, "files", "script-%d.sh", "#!/bin/sh")
+	handler := func(config *goinertia.FileUploadConfig) func(c fiber.Ctx) error {
+		return func(c fiber.Ctx) error {
+			var err error
+			result, err = ta.Inrt.ProcessFileUploads(c, "files", config)
+			if err != nil {
+				return err
+			}
+			return c.SendStatus(http.StatusNoContent)
+		}
+	}
+
+	ta.DoPostBody(handler(&goinertia.FileUploadConfig{
+		AllowedMIMETypes: []string{"image/*"},
+		UploadDir:        t.TempDir(),
+	}), body, map[string]string{fiber.HeaderContentType: writer.FormDataContentType(), "path": "/mime"})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "The file content type text/plain is not allowed.", result.Errors[0].Message)
+
+	ta.DoPostBody(handler(&goinertia.FileUploadConfig{
+		AllowedExtensions: []string{"png", ".JPG"},
+		UploadDir:         t.TempDir(),
+	}), body2, map[string]string{fiber.HeaderContentType: writer2.FormDataContentType(), "path": "/ext"})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "The file must be of type: png, .JPG.", result.Errors[0].Message)
+	assert.Empty(t, result.Files)
+}
+
+func TestProcessFileUploads_ContentContradictsExtension(t *testing.T) {
+	t.Parallel()
+
+	ta := inertiat.NewTestApp(t)
+
+	var result *goinertia.FileUploadResult
+	body, writer := inertiat.CreateBody(t, "files", "image-%d.png",
+		"\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR", "<html><script>alert(1)</script></html>", "fetch('/steal')")
+	//nolint:bodyclose // tests
+	ta.DoPostBody(func(c fiber.Ctx) error {
+		var err error
+		result, err = ta.Inrt.ProcessFileUploads(c, "files", &goinertia.FileUploadConfig{UploadDir: t.TempDir()})
+		if err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusNoContent)
+	}, body, map[string]string{fiber.HeaderContentType: writer.FormDataContentType()})
+
+	// Without AllowedMIMETypes, markup and text are still rejected in a ".png" file.
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "image/png", result.Files[0].MIMEType)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, 1, result.Errors[0].Index)
+	assert.Equal(t, "The file content type text/html is not allowed.", result.Errors[0].Message)
+	assert.Equal(t, 2, result.Errors[1].Index)
+	assert.Equal(t, "The file content type text/plain is not allowed.", result.Errors[1].Message)
+}
+
+func TestProcessFileUploads_StorageError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	storage := inertiamocks.NewMockFileStorage(ctrl)
+	storage.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("disk full"))
+
+	ta := inertiat.NewTestApp(t)
+
+	var processErr error
+	body, writer := inertiat.CreateBody(t, "files", "", "content")
+	ta.DoPostBody(func(c fiber.Ctx) error {
+		_, processErr = ta.Inrt.ProcessFileUploads(c, "files", &goinertia.FileUploadConfig{Storage: storage})
+		return c.SendStatus(http.StatusNoContent)
+	}, body, map[string]string{fiber.HeaderContentType: writer.FormDataContentType()})
+
+	require.ErrorIs(t, processErr, goinertia.ErrUploadStorage)
+}
+
+// failingStorage stores files locally and fails from the failAt-th Save (1-based).
+type failingStorage struct {
+	*goinertia.LocalFileStorage
+
+	saves  int
+	failAt int
+}
+
+func (s *failingStorage) Save(ctx context.Context, name string, src io.Reader) (string, error) {
+	s.saves++
+	if s.saves >= s.failAt {
+		return "", errors.New("disk full")
+	}
+	return s.LocalFileStorage.Save(ctx, name, src)
+}
+
+func TestProcessFileUploads_StorageErrorRemovesStoredFiles(t *testing.T) {
+	t.Parallel()
+
+	uploadDir := t.TempDir()
+	ta := inertiat.NewTestApp(t)
+
+	var (
+		result     *goinertia.FileUploadResult
+		processErr error
+	)
+	body, writer := inertiat.CreateBody(t, "files", "", "one", "two", "three")
+	ta.DoPostBody(func(c fiber.Ctx) error {
+		result, processErr = ta.Inrt.ProcessFileUploads(c, "files", &goinertia.FileUploadConfig{
+			Storage: &failingStorage{LocalFileStorage: goinertia.NewLocalFileStorage(uploadDir), failAt: 3},
+		})
+		return c.SendStatus(http.StatusNoContent)
+	}, body, map[string]string{fiber.HeaderContentType: writer.FormDataContentType()})
+
+	require.ErrorIs(t, processErr, goinertia.ErrUploadStorage)
+	require.NotNil(t, result)
+	assert.Empty(t, result.Files)
+	assert.Empty(t, inertiat.ReadFileContents(t, filepath.Join(uploadDir, "*")))
+}
+
+func TestProcessFileUploads_StorageErrorReturnsStoredFiles(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	storage := inertiamocks.NewMockFileStorage(ctrl)
+	gomock.InOrder(
+		storage.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return("bucket/one", nil),
+		storage.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("disk full")),
+	)
+
+	ta := inertiat.NewTestApp(t)
+
+	var (
+		result     *goinertia.FileUploadResult
+		processErr error
+	)
+	body, writer := inertiat.CreateBody(t, "files", "", "one", "two")
+	ta.DoPostBody(func(c fiber.Ctx) error {
+		result, processErr = ta.Inrt.ProcessFileUploads(c, "files", &goinertia.FileUploadConfig{Storage: storage})
+		return c.SendStatus(http.StatusNoContent)
+	}, body, map[string]string{fiber.HeaderContentType: writer.FormDataContentType()})
+
+	// Without FileRemover, the stored files are returned for the caller to delete.
+	require.ErrorIs(t, processErr, goinertia.ErrUploadStorage)
+	require.NotNil(t, result)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "bucket/one", result.Files[0].Path)
+}
+
+func TestHTTPAdapter_ProcessFileUploads(t *testing.T) {
+	t.Parallel()
+
+	uploadDir := t.TempDir()
+	h := newHTTPTestAdapter(t, func(a *goinertia.HTTPAdapter) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			result, err := a.ProcessFileUploads(r, "files", &goinertia.FileUploadConfig{UploadDir: uploadDir})
+			require.NoError(t, err)
+			a.WithFileUploadSuccess(r, result.Files)
+			require.NoError(t, a.Render(w, r, "Upload/Form", nil))
+		}
+	})
+
+	body, writer := inertiat.CreateBody(t, "files", "", "one", "two")
+	req := inertiat.NewInertiaRequestBody(http.MethodPost, "/upload", body, map[string]string{
+		"Content-Type": writer.FormDataContentType(),
+	})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	page := inertiat.DecodePage(t, rec.Body.String())
+	assert.Equal(t, map[string]any{"success": "2 files uploaded successfully"}, page.Props["flash"])
+	assert.Len(t, inertiat.ReadFileContents(t, filepath.Join(uploadDir, "*")), 2)
+}
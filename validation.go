@@ -0,0 +1,163 @@
+package goinertia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// ErrValidatorNotConfigured is returned by BindAndValidate when no Validator was set with WithValidator.
+var ErrValidatorNotConfigured = errors.New("inertia: validator is not configured")
+
+// ValidatorFunc adapts a function to the Validator interface.
+type ValidatorFunc func(ctx context.Context, value any) (ValidationErrors, error)
+
+func (f ValidatorFunc) Validate(ctx context.Context, value any) (ValidationErrors, error) {
+	return f(ctx, value)
+}
+
+// BindAndValidate parses the request body (JSON, form or multipart) into dst, which must be a pointer,
+// and validates it with the configured Validator. Failed fields are returned as a *ValidationError
+// with 422 status and keys following the json (or form) tags of dst, e.g. "items.0.name".
+//
+// On Precognition requests only the fields listed in Precognition-Validate-Only are kept, and a
+// *ValidationError is returned even when they all pass, so the handler stops before any side effect.
+// MiddlewareErrorListener answers such an error with 204 and Precognition-Success when it holds no field.
+//
+// Example:
+//
+//	var req CreateUserRequest
+//	if err := inrt.BindAndValidate(c, &req); err != nil {
+//		return err
+//	}
+func (i *Inertia) BindAndValidate(c fiber.Ctx, dst any) error {
+	if err := c.Bind().Body(dst); err != nil {
+		return NewError(fiber.StatusBadRequest, "", fmt.Errorf("error binding request body: %w", err))
+	}
+	return i.validate(i.fiberCtx(c), dst)
+}
+
+func (i *Inertia) validate(c requestContext, dst any) error {
+	if i.validator == nil {
+		return ErrValidatorNotConfigured
+	}
+
+	errs, err := i.validator.Validate(c.RequestContext(), dst)
+	if err != nil {
+		return fmt.Errorf("error validating request: %w", err)
+	}
+	errs = i.translateValidationErrors(c, normalizeValidationKeys(reflect.TypeOf(dst), errs))
+
+	if i.isPrecognitionRequest(c) {
+		errs = filterValidationErrors(errs, parseHeaderList(c.Get(HeaderPrecognitionValidateOnly)))
+		if errs == nil {
+			// A non-nil empty set tells renderPrecognitionError that validation passed.
+			errs = ValidationErrors{}
+		}
+		return NewValidationError(fiber.StatusUnprocessableEntity, i.message(c, MessageValidationFailed), errs)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewValidationError(fiber.StatusUnprocessableEntity, i.message(c, MessageValidationFailed), errs)
+}
+
+// normalizeValidationKeys rewrites the keys of errs to the dotted Inertia form (see validationKey).
+func normalizeValidationKeys(typ reflect.Type, errs ValidationErrors) ValidationErrors {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	res := make(ValidationErrors, len(errs))
+	for field, msgs := range errs {
+		key := validationKey(typ, field)
+		res[key] = append(res[key], msgs...)
+	}
+	return res
+}
+
+// translateValidationErrors translates the field messages that are a catalog key or one of the English texts.
+func (i *Inertia) translateValidationErrors(c requestContext, errs ValidationErrors) ValidationErrors {
+	if i.translator == nil {
+		return errs
+	}
+	for _, msgs := range errs {
+		for n, msg := range msgs {
+			msgs[n] = i.translateText(c, msg)
+		}
+	}
+	return errs
+}
+
+// validationKey converts a field path reported by a validator into the key used by the Inertia client.
+// Brackets become dots, Go field names become their json (or form) tag names, and a leading struct
+// type name, as in "CreateUser.Items[0].Name", is dropped. Unknown segments are kept as they are.
+func validationKey(typ reflect.Type, path string) string {
+	path = strings.ReplaceAll(path, "]", "")
+	path = strings.ReplaceAll(path, "[", ".")
+	segments := strings.Split(strings.Trim(path, "."), ".")
+
+	if typ != nil {
+		typ = derefType(typ)
+	}
+	if len(segments) > 1 && typ != nil && typ.Kind() == reflect.Struct && segments[0] == typ.Name() {
+		if _, ok := lookupValidationField(typ, segments[0]); !ok {
+			segments = segments[1:]
+		}
+	}
+
+	keys := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if typ == nil {
+			keys = append(keys, segment)
+			continue
+		}
+
+		switch typ.Kind() {
+		case reflect.Struct:
+			field, ok := lookupValidationField(typ, segment)
+			if !ok {
+				keys = append(keys, segment)
+				typ = nil
+				continue
+			}
+			keys = append(keys, validationFieldName(field))
+			typ = derefType(field.Type)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			keys = append(keys, segment)
+			typ = derefType(typ.Elem())
+		default:
+			keys = append(keys, segment)
+			typ = nil
+		}
+	}
+	return strings.Join(keys, ".")
+}
+
+// lookupValidationField finds an exported field, including promoted ones, by Go name or tag name.
+func lookupValidationField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	if field, ok := typ.FieldByName(name); ok && field.IsExported() {
+		return field, true
+	}
+	for _, field := range reflect.VisibleFields(typ) {
+		if field.IsExported() && !field.Anonymous && validationFieldName(field) == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// validationFieldName returns the json tag name of a field, then the form tag name, then the Go name.
+func validationFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
@@ -0,0 +1,47 @@
+package goinertia
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationKey(t *testing.T) {
+	type address struct {
+		ZipCode string `form:"zip_code"`
+	}
+	type item struct {
+		Name string `json:"name,omitempty"`
+	}
+	type Base struct {
+		ID int `json:"id"`
+	}
+	type CreateUser struct {
+		Base
+		Email   string            `json:"email"`
+		Items   []*item           `json:"items"`
+		Address address           `json:"address"`
+		Meta    map[string]string `json:"meta"`
+		Skipped string            `json:"-"`
+	}
+	typ := reflect.TypeOf(&CreateUser{})
+
+	tests := map[string]string{
+		"Email":                      "email",
+		"CreateUser.Email":           "email",
+		"CreateUser.Items[0].Name":   "items.0.name",
+		"items.1.name":               "items.1.name",
+		"Items[2][Name]":             "items.2.name",
+		"Address.ZipCode":            "address.zip_code",
+		"ID":                         "id",
+		"Meta[color]":                "meta.color",
+		"Skipped":                    "Skipped",
+		"Unknown.Nested[0]":          "Unknown.Nested.0",
+		"CreateUser.Address.ZipCode": "address.zip_code",
+	}
+	for path, want := range tests {
+		assert.Equal(t, want, validationKey(typ, path), path)
+	}
+	assert.Equal(t, "Items.0", validationKey(nil, "Items[0]"))
+}
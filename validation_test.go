@@ -0,0 +1,165 @@
+package goinertia_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/assurrussa/goinertia"
+	"github.com/assurrussa/goinertia/inertiat"
+)
+
+type createOrderRequest struct {
+	Email string      `json:"email" form:"email"`
+	Items []orderItem `json:"items" form:"items"`
+}
+
+type orderItem struct {
+	Name string `json:"name" form:"name"`
+}
+
+func orderValidator() goinertia.ValidatorFunc {
+	return func(_ context.Context, value any) (goinertia.ValidationErrors, error) {
+		req := value.(*createOrderRequest)
+		errs := goinertia.ValidationErrors{}
+		if !strings.Contains(req.Email, "@") {
+			errs["createOrderRequest.Email"] = []string{"The email must be valid."}
+		}
+		for n, item := range req.Items {
+			if item.Name == "" {
+				errs["createOrderRequest.Items["+strconv.Itoa(n)+"].Name"] = []string{"The name is required."}
+			}
+		}
+		return errs, nil
+	}
+}
+
+func TestInertia_BindAndValidate(t *testing.T) {
+	ta := inertiat.NewTestAppWithoutMiddleware(t, goinertia.WithValidator(orderValidator()))
+
+	var bound createOrderRequest
+	var validateErr error
+	handler := func(c fiber.Ctx) error {
+		bound = createOrderRequest{}
+		validateErr = ta.Inrt.BindAndValidate(c, &bound)
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+	jsonHeaders := func(path string) map[string]string {
+		return map[string]string{"path": path, fiber.HeaderContentType: fiber.MIMEApplicationJSON}
+	}
+
+	//nolint:bodyclose // tests
+	ta.DoPostBody(handler, strings.NewReader(`{"email":"bad","items":[{"name":"a"},{"name":""}]}`), jsonHeaders("/json"))
+	var validationErr *goinertia.ValidationError
+	require.ErrorAs(t, validateErr, &validationErr)
+	assert.Equal(t, goinertia.ValidationErrors{
+		"email":        {"The email must be valid."},
+		"items.1.name": {"The name is required."},
+	}, validationErr.Errors())
+	assert.Equal(t, "a", bound.Items[0].Name)
+
+	//nolint:bodyclose // tests
+	ta.DoPostBody(handler, strings.NewReader("email=user%40example.com"), map[string]string{
+		"path":                  "/form",
+		fiber.HeaderContentType: fiber.MIMEApplicationForm,
+	})
+	require.NoError(t, validateErr)
+	assert.Equal(t, "user@example.com", bound.Email)
+
+	//nolint:bodyclose // tests
+	ta.DoPostBody(handler, strings.NewReader(`{"email":`), jsonHeaders("/broken"))
+	var inertiaErr *goinertia.Error
+	require.ErrorAs(t, validateErr, &inertiaErr)
+	assert.Equal(t, fiber.StatusBadRequest, inertiaErr.Code)
+}
+
+func TestInertia_BindAndValidate_WithoutValidator(t *testing.T) {
+	ta := inertiat.NewTestAppWithoutMiddleware(t)
+
+	var validateErr error
+	//nolint:bodyclose // tests
+	ta.DoPostBody(func(c fiber.Ctx) error {
+		validateErr = ta.Inrt.BindAndValidate(c, &createOrderRequest{})
+		return c.SendStatus(fiber.StatusNoContent)
+	}, strings.NewReader(`{}`), map[string]string{fiber.HeaderContentType: fiber.MIMEApplicationJSON})
+	require.ErrorIs(t, validateErr, goinertia.ErrValidatorNotConfigured)
+}
+
+func TestInertia_BindAndValidate_Localized(t *testing.T) {
+	ta := inertiat.NewTestAppWithoutMiddleware(t,
+		goinertia.WithTranslator(goinertia.NewDefaultTranslator()),
+		goinertia.WithValidator(goinertia.ValidatorFunc(func(context.Context, any) (goinertia.ValidationErrors, error) {
+			return goinertia.ValidationErrors{
+				"Email": {goinertia.MessageValidationRequired, "This field must be a valid email address."},
+				"Items": {"Custom message"},
+			}, nil
+		})),
+	)
+
+	var validateErr error
+	//nolint:bodyclose // tests
+	ta.DoPostBody(func(c fiber.Ctx) error {
+		validateErr = ta.Inrt.BindAndValidate(c, &createOrderRequest{})
+		return c.SendStatus(fiber.StatusNoContent)
+	}, strings.NewReader(`{}`), map[string]string{
+		fiber.HeaderContentType:    fiber.MIMEApplicationJSON,
+		fiber.HeaderAcceptLanguage: "ru",
+	})
+
+	var validationErr *goinertia.ValidationError
+	require.ErrorAs(t, validateErr, &validationErr)
+	assert.Equal(t, "Переданные данные некорректны.", validationErr.Error())
+	assert.Equal(t, goinertia.ValidationErrors{
+		"email": {"Поле обязательно для заполнения.", "Поле должно содержать корректный адрес электронной почты."},
+		"items": {"Custom message"},
+	}, validationErr.Errors())
+}
+
+func TestInertia_BindAndValidate_Precognition(t *testing.T) {
+	ta := inertiat.NewTestAppWithErrorHandler(t, goinertia.WithValidator(orderValidator()))
+
+	created := 0
+	handler := func(c fiber.Ctx) error {
+		var req createOrderRequest
+		if err := ta.Inrt.BindAndValidate(c, &req); err != nil {
+			return err
+		}
+		created++
+		return c.SendStatus(fiber.StatusCreated)
+	}
+	body := `{"email":"bad","items":[{"name":""}]}`
+
+	//nolint:bodyclose // tests
+	resp, respBody := ta.DoPostBody(handler, strings.NewReader(body), map[string]string{
+		"path":                                   "/only-email",
+		fiber.HeaderContentType:                  fiber.MIMEApplicationJSON,
+		goinertia.HeaderPrecognition:             "true",
+		goinertia.HeaderPrecognitionValidateOnly: "email",
+	})
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+	var payload struct {
+		Errors map[string][]string `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(respBody), &payload))
+	assert.Equal(t, map[string][]string{"email": {"The email must be valid."}}, payload.Errors)
+
+	// The requested field passes, so the request succeeds without running the handler.
+	//nolint:bodyclose // tests
+	resp, _ = ta.DoPostBody(handler, strings.NewReader(`{"email":"user@example.com","items":[{"name":""}]}`),
+		map[string]string{
+			"path":                                   "/valid-email",
+			fiber.HeaderContentType:                  fiber.MIMEApplicationJSON,
+			goinertia.HeaderPrecognition:             "true",
+			goinertia.HeaderPrecognitionValidateOnly: "email",
+		})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get(goinertia.HeaderPrecognitionSuccess))
+	assert.Zero(t, created)
+}
@@ -0,0 +1,200 @@
+package goinertia
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// DefaultViteManifestPath is the location of the Vite build manifest inside the public FS.
+const DefaultViteManifestPath = "dist/.vite/manifest.json"
+
+// viteChunk is a single entry of the Vite build manifest.
+type viteChunk struct {
+	File    string   `json:"file"`
+	Src     string   `json:"src,omitempty"`
+	IsEntry bool     `json:"isEntry,omitempty"`
+	Imports []string `json:"imports,omitempty"`
+	CSS     []string `json:"css,omitempty"`
+}
+
+type viteManifest map[string]viteChunk
+
+// loadViteManifest reads the manifest from the public FS.
+// A missing manifest is not an error: templates fall back to unhashed asset paths. Other read errors are
+// returned and, outside dev mode, the manifest is read again on the next call.
+func (i *Inertia) loadViteManifest() (viteManifest, error) {
+	read := func() (viteManifest, error) {
+		publicFSRead := os.ReadFile
+		if i.publicFS != nil {
+			publicFSRead = i.publicFS.ReadFile
+		}
+		data, err := publicFSRead(i.viteManifestPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			// A missing manifest means assets are not built with Vite.
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading vite manifest: %w", err)
+		}
+
+		manifest := make(viteManifest)
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("error parsing vite manifest: %w", err)
+		}
+		return manifest, nil
+	}
+
+	if i.isDev {
+		return read()
+	}
+
+	i.viteManifestMu.Lock()
+	defer i.viteManifestMu.Unlock()
+	if !i.viteManifestLoaded {
+		manifest, err := read()
+		if err != nil {
+			return nil, err
+		}
+		i.viteManifest, i.viteManifestLoaded = manifest, true
+	}
+
+	return i.viteManifest, nil
+}
+
+// viteAsset resolves path through the Vite manifest and falls back to the plain asset path.
+func (i *Inertia) viteAsset(path string) (string, error) {
+	manifest, err := i.loadViteManifest()
+	if err != nil {
+		return "", err
+	}
+	if chunk, ok := manifest[path]; ok {
+		return asset(chunk.File)
+	}
+
+	return asset(path)
+}
+
+// viteTags renders the tags for the given entry points.
+// With a running dev server it points to hotServerUrl + "/@vite/client", otherwise
+// it emits the hashed entry, its imported chunks as modulepreload and the associated CSS.
+func (i *Inertia) viteTags(entries ...string) (template.HTML, error) {
+	var b strings.Builder
+
+	if hotURL := strings.TrimRight(i.hotServerURL(), "/"); hotURL != "" {
+		writeViteScript(&b, hotURL+"/@vite/client")
+		for _, entry := range entries {
+			writeViteScript(&b, hotURL+"/"+strings.TrimLeft(entry, "/"))
+		}
+		// #nosec G203 - attribute values are escaped above
+		return template.HTML(b.String()), nil
+	}
+
+	manifest, err := i.loadViteManifest()
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		if manifest == nil {
+			if err := writeViteFallback(&b, entry); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		chunk, ok := manifest[entry]
+		if !ok {
+			return "", fmt.Errorf("vite: entry %q not found in manifest", entry)
+		}
+		if err := writeViteEntry(&b, manifest, chunk); err != nil {
+			return "", err
+		}
+	}
+
+	// #nosec G203 - attribute values are escaped in the writers
+	return template.HTML(b.String()), nil
+}
+
+func writeViteFallback(b *strings.Builder, entry string) error {
+	src, err := asset(entry)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(entry, ".css") {
+		writeViteStylesheet(b, src)
+		return nil
+	}
+	writeViteScript(b, src)
+	return nil
+}
+
+func writeViteEntry(b *strings.Builder, manifest viteManifest, entry viteChunk) error {
+	seen := make(map[string]struct{})
+	var css, preloads []string
+	var collect func(chunk viteChunk)
+	collect = func(chunk viteChunk) {
+		for _, file := range chunk.CSS {
+			css = appendUnique(css, file)
+		}
+		for _, key := range chunk.Imports {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			imported, ok := manifest[key]
+			if !ok {
+				continue
+			}
+			preloads = appendUnique(preloads, imported.File)
+			collect(imported)
+		}
+	}
+	collect(entry)
+
+	for _, file := range css {
+		href, err := asset(file)
+		if err != nil {
+			return err
+		}
+		writeViteStylesheet(b, href)
+	}
+
+	if strings.HasSuffix(entry.File, ".css") {
+		href, err := asset(entry.File)
+		if err != nil {
+			return err
+		}
+		writeViteStylesheet(b, href)
+		return nil
+	}
+
+	src, err := asset(entry.File)
+	if err != nil {
+		return err
+	}
+	writeViteScript(b, src)
+
+	for _, file := range preloads {
+		href, err := asset(file)
+		if err != nil {
+			return err
+		}
+		b.WriteString(`<link rel="modulepreload" href="` + template.HTMLEscapeString(href) + `">` + "\n")
+	}
+
+	return nil
+}
+
+func writeViteScript(b *strings.Builder, src string) {
+	b.WriteString(`<script type="module" src="` + template.HTMLEscapeString(src) + `"></script>` + "\n")
+}
+
+func writeViteStylesheet(b *strings.Builder, href string) {
+	b.WriteString(`<link rel="stylesheet" href="` + template.HTMLEscapeString(href) + `">` + "\n")
+}
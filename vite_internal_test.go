@@ -0,0 +1,192 @@
+package goinertia
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testViteManifest = `{
+  "src/app.js": {
+    "file": "assets/app-4ed993c7.js",
+    "src": "src/app.js",
+    "isEntry": true,
+    "imports": ["_vendor-2c3b1a.js"],
+    "css": ["assets/app-5f2a1c.css"]
+  },
+  "_vendor-2c3b1a.js": {
+    "file": "assets/vendor-2c3b1a.js",
+    "imports": ["_shared-9a8b7c.js"],
+    "css": ["assets/vendor-7d6e5f.css"]
+  },
+  "_shared-9a8b7c.js": {
+    "file": "assets/shared-9a8b7c.js",
+    "imports": ["_vendor-2c3b1a.js"]
+  },
+  "src/style.css": {
+    "file": "assets/style-1a2b3c.css",
+    "src": "src/style.css",
+    "isEntry": true
+  }
+}`
+
+func newViteTestFS(t *testing.T, files map[string]string) fs.ReadFileFS {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	publicFS, ok := os.DirFS(tmpDir).(fs.ReadFileFS)
+	require.True(t, ok)
+
+	return publicFS
+}
+
+func TestVite_Asset(t *testing.T) {
+	t.Parallel()
+
+	inrt := New("http://example.com", WithPublicFS(newViteTestFS(t, map[string]string{
+		DefaultViteManifestPath: testViteManifest,
+	})))
+
+	path, err := inrt.viteAsset("src/app.js")
+	require.NoError(t, err)
+	assert.Equal(t, "/public/dist/assets/app-4ed993c7.js", path)
+
+	path, err = inrt.viteAsset("favicon.ico")
+	require.NoError(t, err)
+	assert.Equal(t, "/public/dist/favicon.ico", path)
+}
+
+func TestVite_Tags_Manifest(t *testing.T) {
+	t.Parallel()
+
+	inrt := New("http://example.com", WithPublicFS(newViteTestFS(t, map[string]string{
+		"build/manifest.json": testViteManifest,
+	})), WithViteManifest("build/manifest.json"))
+
+	html, err := inrt.viteTags("src/app.js", "src/style.css")
+	require.NoError(t, err)
+	assert.Equal(t, `<link rel="stylesheet" href="/public/dist/assets/app-5f2a1c.css">
+<link rel="stylesheet" href="/public/dist/assets/vendor-7d6e5f.css">
+<script type="module" src="/public/dist/assets/app-4ed993c7.js"></script>
+<link rel="modulepreload" href="/public/dist/assets/vendor-2c3b1a.js">
+<link rel="modulepreload" href="/public/dist/assets/shared-9a8b7c.js">
+<link rel="stylesheet" href="/public/dist/assets/style-1a2b3c.css">
+`, string(html))
+
+	_, err = inrt.viteTags("src/missing.js")
+	require.Error(t, err)
+}
+
+func TestVite_Tags_HotServer(t *testing.T) {
+	t.Parallel()
+
+	inrt := New("http://example.com", WithPublicFS(newViteTestFS(t, map[string]string{
+		"hot":                   "http://localhost:5173/\n",
+		DefaultViteManifestPath: testViteManifest,
+	})))
+
+	html, err := inrt.viteTags("src/app.js")
+	require.NoError(t, err)
+	assert.Equal(t, `<script type="module" src="http://localhost:5173/@vite/client"></script>
+<script type="module" src="http://localhost:5173/src/app.js"></script>
+`, string(html))
+}
+
+func TestVite_Tags_WithoutManifest(t *testing.T) {
+	t.Parallel()
+
+	inrt := New("http://example.com", WithPublicFS(newViteTestFS(t, map[string]string{})))
+
+	html, err := inrt.viteTags("app.js", "app.css")
+	require.NoError(t, err)
+	assert.Equal(t, `<script type="module" src="/public/dist/app.js"></script>
+<link rel="stylesheet" href="/public/dist/app.css">
+`, string(html))
+}
+
+func TestVite_InvalidManifest(t *testing.T) {
+	t.Parallel()
+
+	inrt := New("http://example.com", WithPublicFS(newViteTestFS(t, map[string]string{
+		DefaultViteManifestPath: "{invalid",
+	})))
+
+	_, err := inrt.viteAsset("src/app.js")
+	require.Error(t, err)
+
+	_, err = inrt.viteTags("src/app.js")
+	require.Error(t, err)
+}
+
+// flakyReadFS fails the first ReadFile calls with a permission error.
+type flakyReadFS struct {
+	fstest.MapFS
+
+	failures int
+}
+
+func (f *flakyReadFS) ReadFile(name string) ([]byte, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return f.MapFS.ReadFile(name)
+}
+
+func TestVite_ManifestReadError(t *testing.T) {
+	t.Parallel()
+
+	fsys := &flakyReadFS{MapFS: fstest.MapFS{
+		DefaultViteManifestPath: {Data: []byte(`{"src/app.js":{"file":"assets/app-1.js"}}`)},
+	}, failures: 1}
+	inrt := New("http://example.com", WithPublicFS(fsys))
+
+	// Only a missing manifest falls back to the plain asset path.
+	_, err := inrt.viteAsset("src/app.js")
+	require.ErrorIs(t, err, fs.ErrPermission)
+
+	// The error is not cached: the next call reads the manifest again and keeps it.
+	path, err := inrt.viteAsset("src/app.js")
+	require.NoError(t, err)
+	assert.Equal(t, "/public/dist/assets/app-1.js", path)
+
+	fsys.MapFS = fstest.MapFS{}
+	path, err = inrt.viteAsset("src/app.js")
+	require.NoError(t, err)
+	assert.Equal(t, "/public/dist/assets/app-1.js", path)
+}
+
+func TestVite_DevMode_ReloadsManifest(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	manifestPath := filepath.Join(tmpDir, "manifest.json")
+	writeManifest := func(file string) {
+		require.NoError(t, os.WriteFile(manifestPath, []byte(`{"src/app.js":{"file":"`+file+`"}}`), 0o600))
+	}
+	publicFS, ok := os.DirFS(tmpDir).(fs.ReadFileFS)
+	require.True(t, ok)
+
+	writeManifest("app-1.js")
+	inrt := New("http://example.com", WithPublicFS(publicFS), WithViteManifest("manifest.json"), WithDevMode())
+
+	path, err := inrt.viteAsset("src/app.js")
+	require.NoError(t, err)
+	assert.Equal(t, "/public/dist/app-1.js", path)
+
+	writeManifest("app-2.js")
+	path, err = inrt.viteAsset("src/app.js")
+	require.NoError(t, err)
+	assert.Equal(t, "/public/dist/app-2.js", path)
+}